@@ -0,0 +1,199 @@
+package godot
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzer is a go/analysis analyzer, that runs the linter with default
+// settings. Settings can be changed using analyzer flags.
+var Analyzer = NewAnalyzer(Settings{
+	Scope:  DeclScope,
+	Period: true,
+})
+
+// NewAnalyzer creates a go/analysis analyzer, that reports issues as
+// diagnostics with suggested fixes. The settings are used as default values
+// for analyzer flags.
+func NewAnalyzer(settings Settings) *analysis.Analyzer {
+	a := &analysis.Analyzer{
+		Name: "godot",
+		Doc:  "check if comments end in a period",
+		URL:  "https://github.com/tetafro/godot",
+	}
+
+	s := settings
+	s.Exclude = append([]string(nil), settings.Exclude...)
+	s.Endings = append([]string(nil), settings.Endings...)
+	s.Abbreviations = append([]string(nil), settings.Abbreviations...)
+	if settings.MissingDoc.SkipMethods != nil {
+		s.MissingDoc.SkipMethods = append([]string{}, settings.MissingDoc.SkipMethods...)
+	}
+	a.Flags.Var((*scopeFlag)(&s.Scope), "scope",
+		"which comments to check: declarations, exported, toplevel, noinline, all")
+	a.Flags.Var((*listFlag)(&s.Exclude), "exclude",
+		"regexp for excluding particular comment lines from check (can be repeated)")
+	a.Flags.BoolVar(&s.Period, "period", s.Period,
+		"check periods at the end of sentences")
+	a.Flags.BoolVar(&s.ListPeriod, "list-period", s.ListPeriod,
+		"check periods at the end of list items in declaration comments")
+	a.Flags.BoolVar(&s.Capital, "capital", s.Capital,
+		"check that first letter of each sentence is capital")
+	a.Flags.BoolVar(&s.Name, "name", s.Name,
+		"check that declaration comments begin with the name of the declared identifier")
+	a.Flags.BoolVar(&s.Deprecated, "deprecated", s.Deprecated,
+		"check that deprecation notices are separate paragraphs, that begin with \"Deprecated:\"")
+	a.Flags.BoolVar(&s.BlankLine, "blank-line", s.BlankLine,
+		"check that lists and code blocks are separated from the text by an empty line")
+	a.Flags.BoolVar(&s.Space, "space", s.Space,
+		"check that comment text is separated from // by a space")
+	a.Flags.BoolVar(&s.DuplicateWords, "duplicate-words", s.DuplicateWords,
+		"check that comments don't contain the same word twice in a row")
+	a.Flags.BoolVar(&s.Fields, "fields", s.Fields,
+		"check doc comments of exported struct fields and interface methods")
+	a.Flags.BoolVar(&s.MissingDoc.Enabled, "missing-doc", s.MissingDoc.Enabled,
+		"report exported declarations without doc comments")
+	a.Flags.BoolVar(&s.MissingDoc.Tests, "missing-doc-tests", s.MissingDoc.Tests,
+		"report missing doc comments in test files")
+	a.Flags.BoolVar(&s.MissingDoc.Main, "missing-doc-main", s.MissingDoc.Main,
+		"report missing doc comments in main packages")
+	a.Flags.Var((*listFlag)(&s.MissingDoc.SkipMethods), "missing-doc-skip-method",
+		"method, that doesn't need a doc comment, replaces the default list (can be repeated)")
+	a.Flags.BoolVar(&s.PackageDoc, "package-doc", s.PackageDoc,
+		"check the package comment")
+	a.Flags.BoolVar(&s.DocLinks, "doc-links", s.DocLinks,
+		"check that doc links refer to existing identifiers")
+	a.Flags.Var((*listFlag)(&s.Endings), "ending",
+		"additional valid sentence ending (can be repeated)")
+	a.Flags.BoolVar(&s.ReplaceEndings, "replace-endings", s.ReplaceEndings,
+		"replace the default list of sentence endings instead of extending it")
+	a.Flags.Var((*listFlag)(&s.Abbreviations), "abbreviation",
+		"additional abbreviation for the capital letters check (can be repeated)")
+	a.Flags.BoolVar(&s.ReplaceAbbreviations, "replace-abbreviations", s.ReplaceAbbreviations,
+		"replace the default list of abbreviations instead of extending it")
+	a.Flags.BoolVar(&s.CheckGenerated, "check-generated", s.CheckGenerated,
+		"check generated files")
+
+	a.Run = func(pass *analysis.Pass) (interface{}, error) {
+		return nil, runAnalyzer(pass, s)
+	}
+
+	return a
+}
+
+// runAnalyzer runs the linter on every file of the package, and reports
+// the issues.
+func runAnalyzer(pass *analysis.Pass, settings Settings) error {
+	srcs := make([][]byte, len(pass.Files))
+	for i, file := range pass.Files {
+		// Use the source provided by the driver, it may differ from
+		// the file on disk, e.g. for unsaved files in gopls
+		if pass.ReadFile != nil {
+			var err error
+			srcs[i], err = pass.ReadFile(getFilename(pass.Fset, file))
+			if err != nil {
+				return fmt.Errorf("read file: %w", err)
+			}
+		}
+		issues, err := run(file, pass.Fset, srcs[i], settings)
+		if err != nil {
+			return fmt.Errorf("run linter: %w", err)
+		}
+		reportIssues(pass, issues)
+	}
+
+	issues, err := runPackage(pass.Files, pass.Fset, srcs, pass.Pkg, settings)
+	if err != nil {
+		return fmt.Errorf("run package linter: %w", err)
+	}
+	reportIssues(pass, issues)
+
+	return nil
+}
+
+// reportIssues reports issues as diagnostics.
+func reportIssues(pass *analysis.Pass, issues []Issue) {
+	for _, iss := range issues {
+		for _, file := range pass.Files {
+			if getFilename(pass.Fset, file) == iss.Pos.Filename {
+				pass.Report(newDiagnostic(pass.Fset.File(file.Pos()), iss))
+				break
+			}
+		}
+	}
+}
+
+// newDiagnostic converts an issue to a diagnostic. The issue edits become
+// a suggested fix.
+func newDiagnostic(tf *token.File, iss Issue) analysis.Diagnostic {
+	// Issue offset points to the start of the line, and the column is
+	// a byte position inside this line
+	pos := clampOffset(tf, iss.Pos.Offset+iss.Pos.Column-1)
+
+	diag := analysis.Diagnostic{
+		Pos:      tf.Pos(pos),
+		Category: iss.Rule,
+		Message:  iss.Message,
+	}
+	if len(iss.Edits) == 0 {
+		return diag
+	}
+
+	edits := make([]analysis.TextEdit, len(iss.Edits))
+	for i, e := range iss.Edits {
+		edits[i] = analysis.TextEdit{
+			Pos:     tf.Pos(clampOffset(tf, e.Pos.Offset)),
+			End:     tf.Pos(clampOffset(tf, e.End.Offset)),
+			NewText: []byte(e.NewText),
+		}
+	}
+	diag.SuggestedFixes = []analysis.SuggestedFix{{
+		Message:   "Fix comment",
+		TextEdits: edits,
+	}}
+	return diag
+}
+
+// clampOffset limits the offset to the file size to avoid panics in
+// case of broken consistency, e.g. by the `//line` directive.
+func clampOffset(tf *token.File, offset int) int {
+	if offset < 0 {
+		return 0
+	}
+	if offset > tf.Size() {
+		return tf.Size()
+	}
+	return offset
+}
+
+// scopeFlag is a flag value for the scope setting.
+type scopeFlag Scope
+
+func (f *scopeFlag) String() string {
+	return string(*f)
+}
+
+func (f *scopeFlag) Set(s string) error {
+	scope, err := ParseScope(s)
+	if err != nil {
+		return err
+	}
+	*f = scopeFlag(scope)
+	return nil
+}
+
+// listFlag is a flag value for list settings, every flag occurrence
+// adds a new value to the list.
+type listFlag []string
+
+func (f *listFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *listFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
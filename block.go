@@ -0,0 +1,160 @@
+package godot
+
+import (
+	doccomment "go/doc/comment"
+	"regexp"
+	"strings"
+)
+
+// blockKind is a kind of a doc comment block.
+type blockKind int
+
+// List of doc comment blocks, see https://go.dev/doc/comment.
+const (
+	paragraphBlock blockKind = iota
+	headingBlock
+	listItemBlock
+	codeBlock
+)
+
+// block is a block of a doc comment. Lines are indexes of the comment
+// lines, starting at 0.
+type block struct {
+	kind  blockKind
+	first int // first line of the block
+	last  int // last line of the block, inclusive
+}
+
+var (
+	// Link definition, e.g. "[Go]: https://go.dev".
+	linkDef = regexp.MustCompile(`^\s*\[[^\]]+\]:\s+\S+\s*$`)
+
+	// Marker of a list item, e.g. "  - " or "  1. ".
+	listMarker = regexp.MustCompile(`^\s*(?:[-*+•]|\d+[.)])\s+`)
+
+	// Parser replaces pairs of backticks and single quotes with
+	// typographic quotes, this replacer restores them.
+	quotes = strings.NewReplacer("“", "``", "”", "''")
+)
+
+// getBlocks parses the declaration comment using go/doc/comment, and finds
+// lines of every block. Only line comments are parsed, block comments don't
+// have reliable line mapping. If the parsed structure doesn't match
+// the lines, nil is returned, and the comment is checked as a whole.
+func getBlocks(c comment) []block {
+	if !c.decl || c.text == "" || strings.Count(c.text, "\n")+1 != len(c.lines) {
+		return nil
+	}
+	lines := make([]string, len(c.lines))
+	for i, line := range c.lines {
+		line = strings.TrimLeft(line, " \t")
+		if !strings.HasPrefix(line, "//") {
+			return nil
+		}
+		lines[i] = strings.TrimPrefix(line[2:], " ")
+	}
+
+	doc := new(doccomment.Parser).Parse(strings.Join(lines, "\n"))
+
+	var blocks []block
+	i := 0
+	// add adds a block of n lines, that starts after empty lines and link
+	// definitions, and checks that the first line starts with the text
+	add := func(kind blockKind, n int, text string) bool {
+		for i < len(lines) && (strings.TrimSpace(lines[i]) == "" || linkDef.MatchString(lines[i])) {
+			i++
+		}
+		if i+n > len(lines) || n < 1 {
+			return false
+		}
+		line := strings.TrimSpace(lines[i])
+		if kind == listItemBlock {
+			line = listMarker.ReplaceAllString(lines[i], "")
+		}
+		if !strings.Contains(line, quotes.Replace(strings.TrimSpace(text))) {
+			return false
+		}
+		blocks = append(blocks, block{kind: kind, first: i, last: i + n - 1})
+		i += n
+		return true
+	}
+
+	for _, b := range doc.Content {
+		ok := true
+		switch b := b.(type) {
+		case *doccomment.Paragraph:
+			ok = add(paragraphBlock, textLines(b.Text), firstText(b.Text))
+		case *doccomment.Heading:
+			ok = add(headingBlock, 1, firstText(b.Text))
+		case *doccomment.Code:
+			text, _, _ := strings.Cut(b.Text, "\n")
+			ok = add(codeBlock, strings.Count(b.Text, "\n"), text)
+		case *doccomment.List:
+			for _, item := range b.Items {
+				var n int
+				var text string
+				for _, p := range item.Content {
+					if p, isParagraph := p.(*doccomment.Paragraph); isParagraph {
+						n += textLines(p.Text)
+						if text == "" {
+							text = firstText(p.Text)
+						}
+					}
+				}
+				if ok = add(listItemBlock, n, text); !ok {
+					break
+				}
+			}
+		}
+		if !ok {
+			return nil
+		}
+	}
+	return blocks
+}
+
+// textLines returns the number of lines in the text.
+func textLines(text []doccomment.Text) int {
+	n := 1
+	for _, t := range text {
+		switch t := t.(type) {
+		case doccomment.Plain:
+			n += strings.Count(string(t), "\n")
+		case doccomment.Italic:
+			n += strings.Count(string(t), "\n")
+		case *doccomment.Link:
+			n += textLines(t.Text) - 1
+		case *doccomment.DocLink:
+			n += textLines(t.Text) - 1
+		}
+	}
+	return n
+}
+
+// firstText returns the beginning of the text up to the first link
+// or the end of the line. It is used to match the text with the comment
+// lines.
+func firstText(text []doccomment.Text) string {
+	if len(text) == 0 {
+		return ""
+	}
+	var s string
+	switch t := text[0].(type) {
+	case doccomment.Plain:
+		s = string(t)
+	case doccomment.Italic:
+		s = string(t)
+	}
+	s, _, _ = strings.Cut(s, "\n")
+	return s
+}
+
+// blockAt returns the block, that starts at the line.
+func blockAt(blocks []block, line int) (block, bool) {
+	for _, b := range blocks {
+		if b.first == line {
+			return b, true
+		}
+	}
+	return block{}, false
+}
@@ -0,0 +1,145 @@
+package godot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetBlocks(t *testing.T) {
+	testCases := []struct {
+		name    string
+		comment string
+		blocks  []block
+	}{
+		{
+			name:    "paragraph",
+			comment: "// Foo does foo.\n// And more.",
+			blocks:  []block{{kind: paragraphBlock, first: 0, last: 1}},
+		},
+		{
+			name:    "paragraphs",
+			comment: "// Foo does foo.\n//\n// And more.",
+			blocks: []block{
+				{kind: paragraphBlock, first: 0, last: 0},
+				{kind: paragraphBlock, first: 2, last: 2},
+			},
+		},
+		{
+			name:    "heading",
+			comment: "// Foo does foo.\n//\n// # Usage\n//\n// Call it.",
+			blocks: []block{
+				{kind: paragraphBlock, first: 0, last: 0},
+				{kind: headingBlock, first: 2, last: 2},
+				{kind: paragraphBlock, first: 4, last: 4},
+			},
+		},
+		{
+			name:    "list",
+			comment: "// Foo does:\n//   - one\n//   - two\n//     and three",
+			blocks: []block{
+				{kind: paragraphBlock, first: 0, last: 0},
+				{kind: listItemBlock, first: 1, last: 1},
+				{kind: listItemBlock, first: 2, last: 3},
+			},
+		},
+		{
+			name:    "code",
+			comment: "// Foo does foo:\n//\n//\tfoo()\n//\n//\tbar()\n//\n// Done.",
+			blocks: []block{
+				{kind: paragraphBlock, first: 0, last: 0},
+				{kind: codeBlock, first: 2, last: 4},
+				{kind: paragraphBlock, first: 6, last: 6},
+			},
+		},
+		{
+			name:    "links",
+			comment: "// Foo does [Bar] using [Go].\n//\n// [Go]: https://go.dev",
+			blocks:  []block{{kind: paragraphBlock, first: 0, last: 0}},
+		},
+		{
+			name:    "block comment",
+			comment: "/* Foo does foo. */",
+			blocks:  nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := comment{
+				lines: strings.Split(tt.comment, "\n"),
+				text:  tt.comment,
+				decl:  true,
+			}
+			blocks := getBlocks(c)
+			if len(blocks) != len(tt.blocks) {
+				t.Fatalf("Wrong number of blocks\n  expected: %v\n       got: %v",
+					tt.blocks, blocks)
+			}
+			for i := range blocks {
+				if blocks[i] != tt.blocks[i] {
+					t.Fatalf("Wrong block\n  expected: %v\n       got: %v",
+						tt.blocks[i], blocks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckBlocks(t *testing.T) {
+	testCases := []struct {
+		name     string
+		comment  string
+		settings Settings
+		messages []string
+		fixed    string
+	}{
+		{
+			name:     "paragraphs",
+			comment:  "// Foo does foo\n//\n// And more\n",
+			settings: Settings{Scope: DeclScope, Period: true},
+			messages: []string{noPeriodMessage, noPeriodMessage},
+			fixed:    "// Foo does foo.\n//\n// And more.\n",
+		},
+		{
+			name:     "heading",
+			comment:  "// Foo does foo.\n//\n// # Usage\n//\n// Call it.\n",
+			settings: Settings{Scope: DeclScope, Period: true},
+		},
+		{
+			name:     "code block",
+			comment:  "// Foo does foo:\n//\n//\tfoo()\n//\n// Done.\n",
+			settings: Settings{Scope: DeclScope, Period: true},
+		},
+		{
+			name:     "list",
+			comment:  "// Foo does:\n//   - one\n//   - two\n",
+			settings: Settings{Scope: DeclScope, Period: true},
+		},
+		{
+			name:     "list with periods",
+			comment:  "// Foo does:\n//   - one\n//   - two.\n",
+			settings: Settings{Scope: DeclScope, Period: true, ListPeriod: true},
+			messages: []string{noPeriodMessage},
+			fixed:    "// Foo does:\n//   - one.\n//   - two.\n",
+		},
+		{
+			name:     "colon before paragraph",
+			comment:  "// Foo does:\n//\n// Nothing.\n",
+			settings: Settings{Scope: DeclScope, Period: true},
+			messages: []string{noPeriodMessage},
+		},
+		{
+			name:     "capital letter in paragraph",
+			comment:  "// Foo does foo\n//\n// and more.\n",
+			settings: Settings{Scope: DeclScope, Period: true, Capital: true},
+			messages: []string{noPeriodMessage, noCapitalMessage},
+			fixed:    "// Foo does foo.\n//\n// And more.\n",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			testSource(t, tt.settings, tt.comment, "func Foo() {}\n", tt.messages, tt.fixed)
+		})
+	}
+}
@@ -3,28 +3,74 @@ package godot
 import (
 	"go/token"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+)
+
+// Rule names.
+const (
+	PeriodRule  = "period"
+	CapitalRule = "capital"
+	NameRule    = "name"
+
+	// DeprecatedRule is for deprecation notices in declaration comments.
+	DeprecatedRule = "deprecated"
+
+	// MissingDocRule is for exported declarations without doc comments.
+	MissingDocRule = "missing-doc"
+
+	// PackageDocRule is for package comments. It is checked for the whole
+	// package, see RunPackage.
+	PackageDocRule = "package-doc"
+
+	// DocLinkRule is for doc links in comments, e.g. "[Name]". It is
+	// checked for the whole package, see RunPackage.
+	DocLinkRule = "doc-links"
+
+	// BlankLineRule is for lists and code blocks in declaration comments,
+	// that are not separated from the text by an empty line.
+	BlankLineRule = "blank-line"
+
+	// DuplicateWordsRule is for repeated words in comments, e.g. "the the".
+	DuplicateWordsRule = "duplicate-words"
+
+	// SpaceRule is for line comments without a space after "//".
+	SpaceRule = "space"
+
+	// DirectiveRule is for issues with godot directives in comments,
+	// e.g. "//godot:ignore".
+	DirectiveRule = "directive"
 )
 
 // Error messages.
 const (
 	noPeriodMessage  = "Comment should end in a period"
 	noCapitalMessage = "Sentence should start with a capital letter"
+	noNameMessage    = "Comment should begin with the name of the declared identifier"
+
+	noBlankLineMessage = "List or code block should be separated from the text by an empty line"
+	noSpaceMessage     = "Comment text should be separated from // by a space"
+
+	missingDocMessage = "Exported identifier %s should have a doc comment"
 )
 
 var (
-	// List of valid sentence ending.
+	// Default list of valid sentence endings.
 	// A sentence can be inside parenthesis, and therefore ends with parenthesis.
 	lastChars = []string{".", "?", "!", ".)", "?)", "!)", "。", "？", "！", "。）", "？）", "！）", specialReplacer}
 
-	// Abbreviations to exclude from capital letters check.
+	// Default list of abbreviations to exclude from capital letters check.
 	abbreviations = []string{
 		"i.e.", "i. e.", "e.g.", "e. g.", "etc.",
 		"I.e.", "I. e.", "E.g.", "E. g.", "Etc.",
 		"I.E.", "I. E.", "E.G.", "E. G.", "ETC.",
 	}
 
+	// Articles, that can precede the name of the declared identifier.
+	articles = []string{"A", "An", "The"}
+
 	// Special tags in comments like "//nolint:", or "//+k8s:".
 	tags = regexp.MustCompile(`^\+?[a-z0-9-]+:`)
 
@@ -33,20 +79,57 @@ var (
 
 	// URL at the end of the line.
 	endURL = regexp.MustCompile(`[a-z]+://[^\s]+$`)
+
+	// Compiler and linter directives without a colon, like "//line"
+	// or "//nolint".
+	directives = regexp.MustCompile(`^(?:line|extern|export|nolint)\b`)
 )
 
 // checkComments checks every comment accordings to the rules from
 // `settings` argument.
 func checkComments(comments []comment, settings Settings) []Issue {
+	endings := settings.endings()
+	abbrs := settings.abbreviationList()
+
 	var issues []Issue
 	for _, c := range comments {
+		// Collect edits of all checks to combine replacements of the lines
+		c.edits = map[int][]TextEdit{}
+		if settings.Period || settings.Capital {
+			c.blocks = getBlocks(c)
+		}
 		if settings.Period {
-			if iss := checkPeriod(c); iss != nil {
-				issues = append(issues, *iss)
+			if iss := checkPeriods(c, endings, settings.ListPeriod); len(iss) > 0 {
+				issues = append(issues, iss...)
 			}
 		}
 		if settings.Capital {
-			if iss := checkCapital(c); len(iss) > 0 {
+			if iss := checkCapital(c, abbrs); len(iss) > 0 {
+				issues = append(issues, iss...)
+			}
+		}
+		if settings.Name {
+			if iss := checkName(c); iss != nil {
+				issues = append(issues, *iss)
+			}
+		}
+		if settings.Deprecated {
+			if iss := checkDeprecated(c); len(iss) > 0 {
+				issues = append(issues, iss...)
+			}
+		}
+		if settings.BlankLine {
+			if iss := checkBlankLine(c); len(iss) > 0 {
+				issues = append(issues, iss...)
+			}
+		}
+		if settings.DuplicateWords {
+			if iss := checkDuplicateWords(c); len(iss) > 0 {
+				issues = append(issues, iss...)
+			}
+		}
+		if settings.Space {
+			if iss := checkSpace(c); len(iss) > 0 {
 				issues = append(issues, iss...)
 			}
 		}
@@ -55,10 +138,10 @@ func checkComments(comments []comment, settings Settings) []Issue {
 }
 
 // checkPeriod checks that the last sentense of the comment ends
-// in a period.
+// in a period, or in one of the other valid endings.
 //
 //nolint:cyclop
-func checkPeriod(c comment) *Issue {
+func checkPeriod(c comment, endings []string) *Issue {
 	lines := strings.Split(c.text, "\n")
 
 	// Check if the comment has any letters. Comments like "---" should not
@@ -94,7 +177,7 @@ func checkPeriod(c comment) *Issue {
 		return nil
 	}
 	// Correct line
-	if hasSuffix(line, lastChars) {
+	if hasSuffix(line, endings) {
 		return nil
 	}
 
@@ -111,12 +194,7 @@ func checkPeriod(c comment) *Issue {
 	// Get the offset of the first symbol in the last line of the comment.
 	// This value is used only in golangci-lint to point to the problem,
 	// and to replace the line when running in auto-fix mode.
-	// For inline comments, the line starts before the comment, so we
-	// subtract the column offset to get the line start.
-	offset := c.start.Offset - (c.start.Column - 1)
-	for i := 0; i < pos.line-1; i++ {
-		offset += len(c.lines[i]) + 1
-	}
+	offset := c.lineOffset(pos.line - 1)
 
 	iss := Issue{
 		Pos: token.Position{
@@ -125,6 +203,7 @@ func checkPeriod(c comment) *Issue {
 			Line:     pos.line + c.start.Line - 1,
 			Column:   pos.column,
 		},
+		Rule:    PeriodRule,
 		Message: noPeriodMessage,
 	}
 
@@ -136,21 +215,64 @@ func checkPeriod(c comment) *Issue {
 		// This should never happen. Avoid panics, skip this check.
 		return nil
 	}
-	iss.Replacement = original[:iss.Pos.Column-1] + "." +
-		original[iss.Pos.Column-1:]
-
-	// Save replacement to raw lines to be able to combine it with
-	// further replacements
-	c.lines[pos.line-1] = iss.Replacement
+	iss.Edits = []TextEdit{insertText(iss.Pos, ".")}
+	c.replace(&iss, pos.line-1)
 
 	return &iss
 }
 
+// checkPeriods checks periods in every paragraph of the declaration
+// comment. Headings and code blocks are skipped, list items are checked
+// only if `listPeriod` is set. A paragraph, that is followed by a list
+// or a code block, can end in a colon. Comments without parsed blocks
+// are checked as a whole.
+func checkPeriods(c comment, endings []string, listPeriod bool) []Issue {
+	if len(c.blocks) == 0 {
+		if iss := checkPeriod(c, endings); iss != nil {
+			return []Issue{*iss}
+		}
+		return nil
+	}
+
+	var issues []Issue
+	text := strings.Split(c.text, "\n")
+	for i, b := range c.blocks {
+		if b.kind == headingBlock || b.kind == codeBlock ||
+			(b.kind == listItemBlock && !listPeriod) {
+			continue
+		}
+
+		// Check the block as a separate comment with other lines removed
+		lines := make([]string, b.last+1)
+		for j := b.first; j <= b.last; j++ {
+			lines[j] = text[j]
+			if b.kind == listItemBlock {
+				// List items are indented, so they are excluded from
+				// the text as code
+				lines[j] = strings.TrimPrefix(rawText(c.lines[j]), " ")
+			}
+		}
+		block := c
+		block.lines = c.lines[:b.last+1]
+		block.text = strings.Join(lines, "\n")
+
+		ends := endings
+		if b.kind == paragraphBlock && i+1 < len(c.blocks) &&
+			(c.blocks[i+1].kind == listItemBlock || c.blocks[i+1].kind == codeBlock) {
+			ends = append(slices.Clip(endings), ":")
+		}
+		if iss := checkPeriod(block, ends); iss != nil {
+			issues = append(issues, *iss)
+		}
+	}
+	return issues
+}
+
 // checkCapital checks that each sentense of the comment starts with
 // a capital letter.
 //
 //nolint:cyclop,funlen,gocognit
-func checkCapital(c comment) []Issue {
+func checkCapital(c comment, abbreviations []string) []Issue {
 	// Remove common abbreviations from the comment
 	for _, abbr := range abbreviations {
 		repl := strings.ReplaceAll(abbr, ".", "_")
@@ -182,6 +304,10 @@ func checkCapital(c comment) []Issue {
 			if state == endChar {
 				state = endOfSentence
 			}
+			// Every paragraph starts a new sentence
+			if b, ok := blockAt(c.blocks, pos.line-1); ok && b.kind == paragraphBlock {
+				state = endOfSentence
+			}
 			continue
 		}
 		if s == "." || s == "!" || s == "?" {
@@ -220,12 +346,7 @@ func checkCapital(c comment) []Issue {
 		// Get the offset of the first symbol in the current issue's line.
 		// This value is used only in golangci-lint to point to the problem,
 		// and to replace the line when running in auto-fix mode.
-		// For inline comments, the line starts before the comment, so we
-		// subtract the column offset to get the line start.
-		offset := c.start.Offset - (c.start.Column - 1)
-		for i := 0; i < pos.line-1; i++ {
-			offset += len(c.lines[i]) + 1
-		}
+		offset := c.lineOffset(pos.line - 1)
 
 		iss := Issue{
 			Pos: token.Position{
@@ -234,6 +355,7 @@ func checkCapital(c comment) []Issue {
 				Line:     pos.line + c.start.Line - 1,
 				Column:   pos.column + c.start.Column - 1,
 			},
+			Rule:    CapitalRule,
 			Message: noCapitalMessage,
 		}
 
@@ -242,17 +364,14 @@ func checkCapital(c comment) []Issue {
 		// the original original.
 		original := c.lines[pos.line-1]
 		col := byteToRuneColumn(original, iss.Pos.Column) - 1
-		rep := string(unicode.ToTitle([]rune(original)[col])) // capital letter
+		letter := []rune(original)[col]
+		rep := string(unicode.ToTitle(letter)) // capital letter
 		if len(original) < iss.Pos.Column-1+len(rep) {
 			// This should never happen. Avoid panics, skip this check.
 			continue
 		}
-		iss.Replacement = original[:iss.Pos.Column-1] + rep +
-			original[iss.Pos.Column-1+len(rep):]
-
-		// Save replacement to raw lines to be able to combine it with
-		// further replacements
-		c.lines[pos.line-1] = iss.Replacement
+		iss.Edits = []TextEdit{replaceText(iss.Pos, utf8.RuneLen(letter), rep)}
+		c.replace(&iss, pos.line-1)
 
 		issues[i] = iss
 	}
@@ -260,6 +379,222 @@ func checkCapital(c comment) []Issue {
 	return issues
 }
 
+// checkName checks that the declaration comment begins with the name of
+// the declared identifier. The name can be preceded by an article.
+func checkName(c comment) *Issue {
+	if len(c.names) == 0 {
+		return nil
+	}
+
+	// Find the first line with text
+	lines := strings.Split(c.text, "\n")
+	var line string
+	var pos position
+	for i := range lines {
+		if strings.TrimSpace(lines[i]) == "" {
+			continue
+		}
+		line = lines[i]
+		pos.line = i + 1
+		break
+	}
+	if pos.line == 0 || strings.Contains(line, specialReplacer) {
+		return nil
+	}
+
+	words := strings.Fields(line)
+	if hasWordPrefix(words[0], c.names) || strings.HasPrefix(words[0], "Deprecated:") {
+		return nil
+	}
+	if len(words) > 1 && slices.Contains(articles, words[0]) && hasWordPrefix(words[1], c.names) {
+		return nil
+	}
+
+	// Shift position to its real value, same as for the period check
+	original := c.lines[pos.line-1]
+	idx := strings.Index(original, line)
+	if idx < 0 {
+		// This should never happen. Avoid panics, skip this check.
+		return nil
+	}
+	pos.column = idx + len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace)) + 1
+
+	offset := c.lineOffset(pos.line - 1)
+
+	iss := Issue{
+		Pos: token.Position{
+			Filename: c.start.Filename,
+			Offset:   offset,
+			Line:     pos.line + c.start.Line - 1,
+			Column:   pos.column,
+		},
+		Rule:    NameRule,
+		Message: noNameMessage,
+	}
+
+	// The first word is the name in a different case, e.g. "sum" for "Sum",
+	// so replace it
+	for _, name := range c.names {
+		if len(words[0]) >= len(name) && strings.EqualFold(words[0][:len(name)], name) &&
+			hasWordPrefix(name+words[0][len(name):], []string{name}) {
+			iss.Edits = []TextEdit{replaceText(iss.Pos, len(name), name)}
+			c.replace(&iss, pos.line-1)
+			return &iss
+		}
+	}
+
+	// Insert the name before the first word. If the first word is a regular
+	// capitalized word, it is not a sentence start anymore, so make it
+	// lowercase, e.g. "Returns sum" -> "Sum returns sum"
+	first, size := utf8.DecodeRuneInString(words[0])
+	next, _ := utf8.DecodeRuneInString(words[0][size:])
+	rep := c.names[0] + " " + string(first)
+	if unicode.IsUpper(first) && unicode.IsLower(next) {
+		rep = c.names[0] + " " + string(unicode.ToLower(first))
+	}
+	iss.Edits = []TextEdit{replaceText(iss.Pos, size, rep)}
+	c.replace(&iss, pos.line-1)
+
+	return &iss
+}
+
+// checkBlankLine checks that lists and code blocks in declaration comments
+// are separated from the preceding text by an empty line.
+func checkBlankLine(c comment) []Issue {
+	if !c.decl || strings.HasPrefix(c.lines[0], "/*") {
+		return nil
+	}
+	text := strings.Split(c.text, "\n")
+	if len(text) != len(c.lines) {
+		return nil
+	}
+
+	var issues []Issue
+	for i := 1; i < len(c.lines); i++ {
+		prev := rawText(c.lines[i-1])
+		if !isIndented(rawText(c.lines[i])) || isIndented(prev) ||
+			strings.TrimSpace(prev) == "" || strings.Contains(text[i-1], specialReplacer) {
+			continue
+		}
+		indent, ok := lineIndent(c.lines[i])
+		if !ok {
+			continue
+		}
+		if _, ok := lineIndent(c.lines[i-1]); !ok {
+			continue
+		}
+		iss := Issue{
+			Pos: token.Position{
+				Filename: c.start.Filename,
+				Offset:   c.lineOffset(i),
+				Line:     i + c.start.Line,
+				Column:   1,
+			},
+			Rule:    BlankLineRule,
+			Message: noBlankLineMessage,
+		}
+		iss.Edits = []TextEdit{insertText(iss.Pos, indent+"//\n")}
+		c.replace(&iss, i)
+		issues = append(issues, iss)
+	}
+	return issues
+}
+
+// checkSpace checks that the text of line comments is separated from
+// the comment symbols by a space. Directives like "//go:generate" or
+// "//line" are skipped.
+func checkSpace(c comment) []Issue {
+	var issues []Issue
+	for i, line := range c.lines {
+		// The first line may contain code before the comment
+		idx := len(line) - len(strings.TrimLeft(line, " \t"))
+		if i == 0 {
+			idx = c.start.Column - 1
+		}
+		if !strings.HasPrefix(line[min(idx, len(line)):], "//") {
+			// Block comments are not checked
+			return issues
+		}
+		text := line[idx+2:]
+		if text == "" || strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t") ||
+			isSpecialLine(line[idx:]) || directives.MatchString(text) ||
+			!strings.ContainsFunc(text, unicode.IsLetter) {
+			continue
+		}
+
+		iss := Issue{
+			Pos: token.Position{
+				Filename: c.start.Filename,
+				Offset:   c.lineOffset(i),
+				Line:     i + c.start.Line,
+				Column:   idx + 3,
+			},
+			Rule:    SpaceRule,
+			Message: noSpaceMessage,
+		}
+		iss.Edits = []TextEdit{insertText(iss.Pos, " ")}
+		c.replace(&iss, i)
+
+		issues = append(issues, iss)
+	}
+	return issues
+}
+
+// isIndented checks if the comment text is indented, i.e. it is a list
+// item or a code block.
+func isIndented(text string) bool {
+	return strings.HasPrefix(text, "  ") ||
+		strings.HasPrefix(text, " \t") ||
+		strings.HasPrefix(text, "\t")
+}
+
+// lineIndent returns the indentation before "//" of the comment line. False
+// is returned if the line is not a line comment, e.g. a line of a block
+// comment in a mixed comment group.
+func lineIndent(line string) (string, bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(trimmed, "//") {
+		return "", false
+	}
+	return line[:len(line)-len(trimmed)], true
+}
+
+// hasWordPrefix checks if the word is one of the names, possibly followed
+// by punctuation, e.g. "Name," or "Name's".
+func hasWordPrefix(word string, names []string) bool {
+	for _, name := range names {
+		if !strings.HasPrefix(word, name) {
+			continue
+		}
+		rest := word[len(name):]
+		if rest == "" {
+			return true
+		}
+		r, _ := utf8.DecodeRuneInString(rest)
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return true
+		}
+	}
+	return false
+}
+
+// insertText creates an edit, that inserts the text at the issue position.
+func insertText(pos token.Position, text string) TextEdit {
+	return replaceText(pos, 0, text)
+}
+
+// replaceText creates an edit, that replaces n bytes at the issue position
+// with the text. Issue position offset points to the start of the line,
+// so the real offset is calculated using the column.
+func replaceText(pos token.Position, n int, text string) TextEdit {
+	start := pos
+	start.Offset += pos.Column - 1
+	end := start
+	end.Offset += n
+	end.Column += n
+	return TextEdit{Pos: start, End: end, NewText: text}
+}
+
 // isSpecialBlock checks that given block of comment lines is special and
 // shouldn't be checked as a regular sentence.
 func isSpecialBlock(comment string) bool {
@@ -291,9 +626,7 @@ func isSpecialLine(comment string) bool {
 
 	// Don't check comments starting with space indentation - they may
 	// contain code examples, which shouldn't end with period
-	if strings.HasPrefix(comment, "  ") ||
-		strings.HasPrefix(comment, " \t") ||
-		strings.HasPrefix(comment, "\t") {
+	if isIndented(comment) {
 		return true
 	}
 
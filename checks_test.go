@@ -2,6 +2,7 @@ package godot
 
 import (
 	"go/token"
+	"reflect"
 	"testing"
 )
 
@@ -236,13 +237,28 @@ func TestCheckPeriod(t *testing.T) {
 				},
 				Message:     noPeriodMessage,
 				Replacement: "  Bar string // some comment.",
+				Edits: []TextEdit{{
+					Pos: token.Position{
+						Filename: "filename.go",
+						Offset:   55,
+						Line:     3,
+						Column:   29,
+					},
+					End: token.Position{
+						Filename: "filename.go",
+						Offset:   55,
+						Line:     3,
+						Column:   29,
+					},
+					NewText: ".",
+				}},
 			},
 		},
 	}
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			issue := checkPeriod(tt.comment)
+			issue := checkPeriod(tt.comment, lastChars)
 			switch {
 			case tt.issue == nil && issue == nil:
 				return
@@ -259,6 +275,9 @@ func TestCheckPeriod(t *testing.T) {
 			case issue.Replacement != tt.issue.Replacement:
 				t.Fatalf("Wrong replacement\n  expected: %s\n       got: %s",
 					tt.issue.Replacement, issue.Replacement)
+			case tt.issue.Edits != nil && !reflect.DeepEqual(issue.Edits, tt.issue.Edits):
+				t.Fatalf("Wrong edits\n  expected: %+v\n       got: %+v",
+					tt.issue.Edits, issue.Edits)
 			}
 		})
 	}
@@ -433,7 +452,7 @@ func TestCheckCapital(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			issues := checkCapital(tt.comment)
+			issues := checkCapital(tt.comment, abbreviations)
 			if len(issues) != len(tt.issues) {
 				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d",
 					len(tt.issues), len(issues))
@@ -448,6 +467,528 @@ func TestCheckCapital(t *testing.T) {
 	}
 }
 
+func TestCheckCustomLists(t *testing.T) {
+	start := token.Position{
+		Filename: "filename.go",
+		Offset:   0,
+		Line:     1,
+		Column:   1,
+	}
+
+	testCases := []struct {
+		name     string
+		text     string
+		settings Settings
+		issues   int
+	}{
+		{
+			name:     "default ending",
+			text:     "Hello, world.",
+			settings: Settings{Period: true},
+			issues:   0,
+		},
+		{
+			name:     "unknown ending",
+			text:     "Hello, world…",
+			settings: Settings{Period: true},
+			issues:   1,
+		},
+		{
+			name:     "additional ending",
+			text:     "Hello, world…",
+			settings: Settings{Period: true, Endings: []string{"…", ":"}},
+			issues:   0,
+		},
+		{
+			name: "replaced endings",
+			text: "Hello, world!",
+			settings: Settings{
+				Period:         true,
+				Endings:        []string{"…", "."},
+				ReplaceEndings: true,
+			},
+			issues: 1,
+		},
+		{
+			name:     "unknown abbreviation",
+			text:     "Tabs vs. spaces.",
+			settings: Settings{Capital: true},
+			issues:   1,
+		},
+		{
+			name:     "additional abbreviation",
+			text:     "Tabs vs. spaces, i.e. holy war.",
+			settings: Settings{Capital: true, Abbreviations: []string{"vs."}},
+			issues:   0,
+		},
+		{
+			name: "replaced abbreviations",
+			text: "Tabs vs. spaces, i.e. holy war.",
+			settings: Settings{
+				Capital:              true,
+				Abbreviations:        []string{"vs."},
+				ReplaceAbbreviations: true,
+			},
+			issues: 1,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := comment{
+				lines: []string{"// " + tt.text},
+				text:  " " + tt.text,
+				start: start,
+			}
+			issues := checkComments([]comment{c}, tt.settings)
+			if len(issues) != tt.issues {
+				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d",
+					tt.issues, len(issues))
+			}
+		})
+	}
+}
+
+func TestCheckName(t *testing.T) {
+	start := token.Position{
+		Filename: "filename.go",
+		Offset:   0,
+		Line:     1,
+		Column:   1,
+	}
+
+	testCases := []struct {
+		name    string
+		comment comment
+		issue   *Issue
+	}{
+		{
+			name: "not a declaration comment",
+			comment: comment{
+				lines: []string{"// Hello, world."},
+				text:  " Hello, world.",
+				start: start,
+			},
+			issue: nil,
+		},
+		{
+			name: "begins with the name",
+			comment: comment{
+				lines: []string{"// Sum sums two integers."},
+				text:  " Sum sums two integers.",
+				start: start,
+				names: []string{"Sum"},
+			},
+			issue: nil,
+		},
+		{
+			name: "begins with the name and punctuation",
+			comment: comment{
+				lines: []string{"// Sum's result is an integer."},
+				text:  " Sum's result is an integer.",
+				start: start,
+				names: []string{"Sum"},
+			},
+			issue: nil,
+		},
+		{
+			name: "begins with the name in lowercase",
+			comment: comment{
+				lines: []string{"// sum returns x."},
+				text:  " sum returns x.",
+				start: start,
+				names: []string{"Sum"},
+			},
+			issue: &Issue{
+				Pos: token.Position{
+					Filename: "filename.go",
+					Offset:   0,
+					Line:     1,
+					Column:   4,
+				},
+				Message:     noNameMessage,
+				Replacement: "// Sum returns x.",
+			},
+		},
+		{
+			name: "begins with an article",
+			comment: comment{
+				lines: []string{"// A Client is an HTTP client."},
+				text:  " A Client is an HTTP client.",
+				start: start,
+				names: []string{"Client"},
+			},
+			issue: nil,
+		},
+		{
+			name: "begins with the receiver",
+			comment: comment{
+				lines: []string{"// Client.Do sends a request."},
+				text:  " Client.Do sends a request.",
+				start: start,
+				names: []string{"Do", "Client.Do"},
+			},
+			issue: nil,
+		},
+		{
+			name: "deprecation note",
+			comment: comment{
+				lines: []string{"// Deprecated: use Sum2 instead."},
+				text:  " Deprecated: use Sum2 instead.",
+				start: start,
+				names: []string{"Sum"},
+			},
+			issue: nil,
+		},
+		{
+			name: "special first line",
+			comment: comment{
+				lines: []string{"//nolint:test", "// Hello, world."},
+				text:  specialReplacer + "\n Hello, world.",
+				start: start,
+				names: []string{"Sum"},
+			},
+			issue: nil,
+		},
+		{
+			name: "name with a longer word",
+			comment: comment{
+				lines: []string{"// Summary of the sum."},
+				text:  " Summary of the sum.",
+				start: start,
+				names: []string{"Sum"},
+			},
+			issue: &Issue{
+				Pos: token.Position{
+					Filename: "filename.go",
+					Offset:   0,
+					Line:     1,
+					Column:   4,
+				},
+				Message:     noNameMessage,
+				Replacement: "// Sum summary of the sum.",
+			},
+		},
+		{
+			name: "lowercase first word",
+			comment: comment{
+				lines: []string{"// sums two integers."},
+				text:  " sums two integers.",
+				start: start,
+				names: []string{"Sum"},
+			},
+			issue: &Issue{
+				Pos: token.Position{
+					Filename: "filename.go",
+					Offset:   0,
+					Line:     1,
+					Column:   4,
+				},
+				Message:     noNameMessage,
+				Replacement: "// Sum sums two integers.",
+			},
+		},
+		{
+			name: "acronym first word",
+			comment: comment{
+				lines: []string{"// HTTP client."},
+				text:  " HTTP client.",
+				start: start,
+				names: []string{"Client"},
+			},
+			issue: &Issue{
+				Pos: token.Position{
+					Filename: "filename.go",
+					Offset:   0,
+					Line:     1,
+					Column:   4,
+				},
+				Message:     noNameMessage,
+				Replacement: "// Client HTTP client.",
+			},
+		},
+		{
+			name: "indented multiline block comment",
+			comment: comment{
+				lines: []string{"\t/*", "\tReturns a value.", "\t*/"},
+				text:  "\n\tReturns a value.\n\t",
+				start: token.Position{
+					Filename: "filename.go",
+					Offset:   20,
+					Line:     3,
+					Column:   2,
+				},
+				names: []string{"Value"},
+			},
+			issue: &Issue{
+				Pos: token.Position{
+					Filename: "filename.go",
+					Offset:   23,
+					Line:     4,
+					Column:   2,
+				},
+				Message:     noNameMessage,
+				Replacement: "\tValue returns a value.",
+				Edits: []TextEdit{{
+					Pos: token.Position{
+						Filename: "filename.go",
+						Offset:   24,
+						Line:     4,
+						Column:   2,
+					},
+					End: token.Position{
+						Filename: "filename.go",
+						Offset:   25,
+						Line:     4,
+						Column:   3,
+					},
+					NewText: "Value r",
+				}},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := checkName(tt.comment)
+			switch {
+			case tt.issue == nil && issue == nil:
+				return
+			case tt.issue == nil && issue != nil:
+				t.Fatalf("Unexpected issue")
+			case tt.issue != nil && issue == nil:
+				t.Fatalf("Expected issue, got nil")
+			case issue.Pos != tt.issue.Pos:
+				t.Fatalf("Wrong position\n  expected: %+v [%d]\n       got: %+v [%d]",
+					tt.issue.Pos, tt.issue.Pos.Offset, issue.Pos, issue.Pos.Offset)
+			case issue.Message != tt.issue.Message:
+				t.Fatalf("Wrong message\n  expected: %s\n       got: %s",
+					tt.issue.Message, issue.Message)
+			case issue.Replacement != tt.issue.Replacement:
+				t.Fatalf("Wrong replacement\n  expected: %s\n       got: %s",
+					tt.issue.Replacement, issue.Replacement)
+			case tt.issue.Edits != nil && !reflect.DeepEqual(issue.Edits, tt.issue.Edits):
+				t.Fatalf("Wrong edits\n  expected: %+v\n       got: %+v",
+					tt.issue.Edits, issue.Edits)
+			}
+		})
+	}
+}
+
+func TestCheckBlankLine(t *testing.T) {
+	testCases := []struct {
+		name        string
+		comment     string
+		messages    []string
+		fixed       string
+		replacement string
+	}{
+		{
+			name:    "separated list",
+			comment: "// Foo does:\n//\n//   - one\n//   - two\n",
+		},
+		{
+			name:        "list",
+			comment:     "// Foo does:\n//   - one\n//   - two\n",
+			messages:    []string{noBlankLineMessage},
+			fixed:       "// Foo does:\n//\n//   - one\n//   - two\n",
+			replacement: "//\n//   - one",
+		},
+		{
+			name:        "code block",
+			comment:     "// Foo does foo, e.g.:\n//\tfoo()\n//\tbar()\n// Done.\n",
+			messages:    []string{noBlankLineMessage},
+			fixed:       "// Foo does foo, e.g.:\n//\n//\tfoo()\n//\tbar()\n// Done.\n",
+			replacement: "//\n//\tfoo()",
+		},
+		{
+			name:        "indented comment",
+			comment:     "\t// Foo does:\n\t//   - one\n",
+			messages:    []string{noBlankLineMessage},
+			fixed:       "\t// Foo does:\n\t//\n\t//   - one\n",
+			replacement: "\t//\n\t//   - one",
+		},
+		{
+			name:    "after tag",
+			comment: "//nolint:all\n//   - one\n",
+		},
+		{
+			name:    "block comment",
+			comment: "/* Foo does:\n  - one\n*/\n",
+		},
+		{
+			name:    "mixed comment group",
+			comment: "// Foo does x.\n/*  code */\n",
+		},
+	}
+
+	settings := Settings{Scope: AllScope, BlankLine: true}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			testSource(t, settings, tt.comment, "var Foo int\n", tt.messages, tt.fixed)
+			if tt.replacement == "" {
+				return
+			}
+			src := "package example\n\n" + tt.comment + "var Foo int\n"
+			issues, err := RunSource("example.go", []byte(src), settings)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if issues[0].Replacement != tt.replacement {
+				t.Fatalf("Wrong replacement\n  expected: %q\n       got: %q",
+					tt.replacement, issues[0].Replacement)
+			}
+		})
+	}
+}
+
+func TestCheckSpace(t *testing.T) {
+	testCases := []struct {
+		name     string
+		comment  string
+		messages []string
+		fixed    string
+	}{
+		{
+			name:    "valid",
+			comment: "// Foo is foo.\n",
+		},
+		{
+			name:     "no space",
+			comment:  "//Foo is foo.\n//It's a number.\n",
+			messages: []string{noSpaceMessage, noSpaceMessage},
+			fixed:    "// Foo is foo.\n// It's a number.\n",
+		},
+		{
+			name:    "directives",
+			comment: "//go:generate echo foo\n//nolint:all\n//nolint\n//export Foo\n//line foo.go:1\n//+build linux\n",
+		},
+		{
+			name:    "empty lines and separators",
+			comment: "// Foo is foo.\n//\n//------\n",
+		},
+		{
+			name:    "block comment",
+			comment: "/*Foo is foo.*/\n",
+		},
+	}
+
+	settings := Settings{Scope: AllScope, Space: true}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			testSource(t, settings, tt.comment, "var Foo int\n", tt.messages, tt.fixed)
+		})
+	}
+
+	t.Run("inline comment", func(t *testing.T) {
+		testSource(t, settings, "var Foo int //foo\n", "",
+			[]string{noSpaceMessage}, "var Foo int // foo\n")
+	})
+}
+
+func TestCheckCombined(t *testing.T) {
+	testCases := []struct {
+		name     string
+		comment  string
+		settings Settings
+		messages []string
+		fixed    string
+	}{
+		{
+			name:     "period and capital",
+			comment:  "// Sum returns x. and y\n",
+			settings: Settings{Scope: DeclScope, Period: true, Capital: true},
+			messages: []string{noCapitalMessage, noPeriodMessage},
+			fixed:    "// Sum returns x. And y.\n",
+		},
+		{
+			name:     "capital and name",
+			comment:  "// returns x. and y.\n",
+			settings: Settings{Scope: DeclScope, Capital: true, Name: true},
+			messages: []string{noNameMessage, noCapitalMessage},
+			fixed:    "// Sum returns x. And y.\n",
+		},
+		{
+			name:     "name and duplicate words",
+			comment:  "// returns the the sum.\n",
+			settings: Settings{Scope: DeclScope, Name: true, DuplicateWords: true},
+			messages: []string{noNameMessage, `Duplicate word "the"`},
+			fixed:    "// Sum returns the sum.\n",
+		},
+		{
+			name:     "name and deprecated",
+			comment:  "// returns sum. deprecated: use Add.\n",
+			settings: Settings{Scope: DeclScope, Name: true, Deprecated: true},
+			messages: []string{noNameMessage, deprecatedParagraphMessage, deprecatedFormatMessage},
+			fixed:    "// Sum returns sum.\n//\n// Deprecated: use Add.\n",
+		},
+		{
+			name:     "name and space",
+			comment:  "//returns x.\n",
+			settings: Settings{Scope: DeclScope, Name: true, Space: true},
+			messages: []string{noNameMessage, noSpaceMessage},
+			fixed:    "// Sum returns x.\n",
+		},
+		{
+			name:     "name in lowercase and space",
+			comment:  "//sum returns x.\n",
+			settings: Settings{Scope: DeclScope, Name: true, Space: true},
+			messages: []string{noNameMessage, noSpaceMessage},
+			fixed:    "// Sum returns x.\n",
+		},
+		{
+			name:    "all rules",
+			comment: "// returns the the sum\n//   - of x\n",
+			settings: Settings{
+				Scope: DeclScope, Period: true, Capital: true, Name: true,
+				BlankLine: true, DuplicateWords: true,
+			},
+			messages: []string{noNameMessage, `Duplicate word "the"`, noPeriodMessage, noBlankLineMessage},
+			fixed:    "// Sum returns the sum.\n//\n//   - of x\n",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			testSource(t, tt.settings, tt.comment, "func Sum() {}\n", tt.messages, tt.fixed)
+		})
+	}
+}
+
+// testSource runs checks on the comment followed by the declaration, and
+// compares issue messages. If the expected fixed comment is not empty, it
+// also checks the fixed source.
+func testSource(t *testing.T, settings Settings, comment, decl string, messages []string, fixed string) {
+	t.Helper()
+
+	src := "package example\n\n" + comment + decl
+	issues, err := RunSource("example.go", []byte(src), settings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != len(messages) {
+		t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d (%v)",
+			len(messages), len(issues), issues)
+	}
+	for i, iss := range issues {
+		if iss.Message != messages[i] {
+			t.Fatalf("Wrong message\n  expected: %s\n       got: %s", messages[i], iss.Message)
+		}
+	}
+
+	if fixed == "" {
+		return
+	}
+	result, err := FixSource("example.go", []byte(src), settings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "package example\n\n" + fixed + decl
+	if string(result) != expected {
+		t.Fatalf("Wrong result\n  expected: %q\n       got: %q", expected, result)
+	}
+}
+
 func TestIsSpecialBlock(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tetafro/godot"
+)
+
+// baseline is a set of known issues, that should not be reported.
+// Issues are identified by file, comment content and message rather than
+// position, so they survive edits in other parts of the file.
+type baseline struct {
+	Issues []baselineIssue `json:"issues"`
+}
+
+type baselineIssue struct {
+	baselineKey
+	Count int `json:"count"`
+}
+
+type baselineKey struct {
+	File    string `json:"file"`    // path relative to the current directory
+	Hash    string `json:"hash"`    // hash of the comment content
+	Message string `json:"message"` // issue message
+}
+
+// baselineFilter filters out issues, that are present in the baseline.
+type baselineFilter struct {
+	known map[baselineKey]int
+}
+
+// readBaseline reads baseline from the file.
+func readBaseline(path string) (*baselineFilter, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("read baseline file: %w", err)
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse baseline file %s: %w", path, err)
+	}
+
+	f := &baselineFilter{known: make(map[baselineKey]int, len(b.Issues))}
+	for _, iss := range b.Issues {
+		f.known[iss.baselineKey] += iss.Count
+	}
+	return f, nil
+}
+
+// filter returns only issues, that are not in the baseline. Every known
+// issue filters out only one issue with the same key.
+func (f *baselineFilter) filter(file *ast.File, fset *token.FileSet, issues []godot.Issue) []godot.Issue {
+	var filtered []godot.Issue
+	for _, iss := range issues {
+		key := newBaselineKey(file, fset, iss)
+		if f.known[key] > 0 {
+			f.known[key]--
+			continue
+		}
+		filtered = append(filtered, iss)
+	}
+	return filtered
+}
+
+// baselineWriter collects issues for a new baseline.
+type baselineWriter struct {
+	counts map[baselineKey]int
+}
+
+func newBaselineWriter() *baselineWriter {
+	return &baselineWriter{counts: map[baselineKey]int{}}
+}
+
+// add adds issues of the file to the baseline.
+func (w *baselineWriter) add(file *ast.File, fset *token.FileSet, issues []godot.Issue) {
+	for _, iss := range issues {
+		w.counts[newBaselineKey(file, fset, iss)]++
+	}
+}
+
+// write writes the baseline to the file.
+func (w *baselineWriter) write(path string) error {
+	b := baseline{Issues: make([]baselineIssue, 0, len(w.counts))}
+	for key, count := range w.counts {
+		b.Issues = append(b.Issues, baselineIssue{baselineKey: key, Count: count})
+	}
+	sort.Slice(b.Issues, func(i, j int) bool {
+		x, y := b.Issues[i], b.Issues[j]
+		if x.File != y.File {
+			return x.File < y.File
+		}
+		if x.Hash != y.Hash {
+			return x.Hash < y.Hash
+		}
+		return x.Message < y.Message
+	})
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gosec
+		return fmt.Errorf("write baseline file: %w", err)
+	}
+	return nil
+}
+
+// newBaselineKey creates a baseline key for the issue.
+func newBaselineKey(file *ast.File, fset *token.FileSet, iss godot.Issue) baselineKey {
+	path := iss.Pos.Filename
+	if abs, err := filepath.Abs(path); err == nil {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, abs); err == nil {
+				path = rel
+			}
+		}
+	}
+	return baselineKey{
+		File:    filepath.ToSlash(path),
+		Hash:    commentHash(file, fset, iss.Pos),
+		Message: iss.Message,
+	}
+}
+
+// commentHash returns a hash of the content of the comment, that contains
+// the position. If there is no such comment, hash of an empty string
+// is returned.
+func commentHash(file *ast.File, fset *token.FileSet, pos token.Position) string {
+	var text string
+	if file != nil {
+		for _, cg := range file.Comments {
+			start := fset.Position(cg.Pos())
+			end := fset.Position(cg.End())
+			if start.Filename != pos.Filename || pos.Line < start.Line || pos.Line > end.Line {
+				continue
+			}
+			lines := make([]string, len(cg.List))
+			for i, c := range cg.List {
+				lines[i] = c.Text
+			}
+			text = strings.Join(lines, "\n")
+			break
+		}
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/tetafro/godot"
+)
+
+func TestBaseline(t *testing.T) {
+	parse := func(src string) ([]godot.Issue, *token.FileSet, *ast.File) {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "main.go", src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("Failed to parse source: %v", err)
+		}
+		issues, err := godot.RunSource("main.go", []byte(src), godot.Settings{
+			Scope:  godot.DeclScope,
+			Period: true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return issues, fset, file
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+
+	// Write baseline for the old version
+	old := "package main\n\n// Foo x\nfunc Foo() {}\n\n// Bar x\nfunc Bar() {}\n"
+	issues, fset, file := parse(old)
+	w := newBaselineWriter()
+	w.add(file, fset, issues)
+	if err := w.write(path); err != nil {
+		t.Fatalf("Failed to write baseline: %v", err)
+	}
+
+	// Lines are shifted, and one comment is changed
+	updated := "package main\n\n// Added.\n\n// Foo x\nfunc Foo() {}\n\n// Bar y\nfunc Bar() {}\n"
+	issues, fset, file = parse(updated)
+	known, err := readBaseline(path)
+	if err != nil {
+		t.Fatalf("Failed to read baseline: %v", err)
+	}
+	filtered := known.filter(file, fset, issues)
+	if len(filtered) != 1 {
+		t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d", 1, len(filtered))
+	}
+	if filtered[0].Pos.Line != 8 {
+		t.Fatalf("Wrong issue: %s", filtered[0].Pos)
+	}
+
+	t.Run("file not found", func(t *testing.T) {
+		_, err := readBaseline(filepath.Join("testdata", "not-exists.json"))
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,449 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/tetafro/godot"
+	"go.yaml.in/yaml/v3"
+)
+
+// configFile is a content of a config file.
+type configFile struct {
+	// Stop searching for config files in parent directories.
+	Root bool `yaml:"root"`
+
+	// Settings for particular paths, applied in order over the top-level
+	// settings.
+	Overrides []override `yaml:"overrides"`
+
+	// Top-level settings. The node is decoded over settings of config files
+	// from parent directories, so only set values are changed.
+	node *yaml.Node
+	path string
+}
+
+// override changes the top-level settings for files, that match any of
+// the paths. Only set values are changed.
+type override struct {
+	// Glob patterns of slash-separated paths relative to the config file
+	// directory. Pattern "**" matches any number of directories,
+	// e.g. "internal/**" or "**/*_test.go".
+	Paths []string `yaml:"paths"`
+
+	Scope   *godot.Scope `yaml:"scope"`
+	Exclude []string     `yaml:"exclude"`
+	Period  *bool        `yaml:"period"`
+	Capital *bool        `yaml:"capital"`
+}
+
+// config is a chain of config files from the farthest to the nearest
+// to linted files.
+type config []*configFile
+
+// configLoader finds config files for linted files. If the config file
+// is set explicitly, it is used for all files. Otherwise config files are
+// searched in the directory of every file and its parents up to the root
+// of the repository, or up to the config file with `root: true`.
+type configLoader struct {
+	explicit config
+
+	mu   sync.Mutex
+	dirs map[string]config // cache of config chains for directories
+}
+
+// newConfigLoader creates a config loader. If the file is not set,
+// config files are searched for every linted file.
+func newConfigLoader(file string) (*configLoader, error) {
+	l := &configLoader{dirs: map[string]config{}}
+	if file == "" {
+		return l, nil
+	}
+	cf, err := readConfigFile(file)
+	if err != nil {
+		return nil, err
+	}
+	l.explicit = config{cf}
+	return l, nil
+}
+
+// settings returns settings for the file.
+func (l *configLoader) settings(file string) (godot.Settings, error) {
+	cfg, err := l.chain(file)
+	if err != nil {
+		return godot.Settings{}, err
+	}
+	return cfg.settings(file)
+}
+
+// chain returns the chain of config files for the file or the directory.
+func (l *configLoader) chain(path string) (config, error) {
+	if l.explicit != nil {
+		return l.explicit, nil
+	}
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		path = filepath.Dir(path)
+	}
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("get absolute path: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.find(dir)
+}
+
+// find finds the chain of config files for the absolute directory path.
+func (l *configLoader) find(dir string) (config, error) {
+	if cfg, ok := l.dirs[dir]; ok {
+		return cfg, nil
+	}
+
+	var cfg config
+	cf, err := readConfigFile(filepath.Join(dir, defaultConfigFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		cf = nil
+	case err != nil:
+		return nil, err
+	}
+
+	// Search in parents, until the root of the repository, or the root
+	// of the file system
+	parent := filepath.Dir(dir)
+	_, err = os.Stat(filepath.Join(dir, ".git"))
+	isRepoRoot := err == nil
+	if (cf == nil || !cf.Root) && !isRepoRoot && parent != dir {
+		cfg, err = l.find(parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cf != nil {
+		cfg = append(cfg[:len(cfg):len(cfg)], cf)
+	}
+
+	l.dirs[dir] = cfg
+	return cfg, nil
+}
+
+// readConfigFile reads and parses the config file. Unknown fields and
+// invalid values are reported with line numbers.
+func readConfigFile(file string) (*configFile, error) {
+	data, err := os.ReadFile(file) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("read config file %s: %w", file, err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, configError(file, err)
+	}
+	if node.Kind == 0 {
+		// Empty file
+		return &configFile{node: &node, path: file}, nil
+	}
+
+	// Decode the whole file to check fields and their types
+	var strict struct {
+		godot.Settings `yaml:",inline"`
+		configFile     `yaml:",inline"`
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&strict); err != nil {
+		return nil, configError(file, err)
+	}
+	if err := checkValues(file, &node); err != nil {
+		return nil, err
+	}
+
+	cf := strict.configFile
+	cf.node = &node
+	cf.path = file
+	return &cf, nil
+}
+
+// checkValues checks values of settings, that are not checked during
+// decoding: scopes, regexps and path patterns.
+func checkValues(file string, node *yaml.Node) error {
+	var errs []error
+	report := func(n *yaml.Node, err error) {
+		errs = append(errs, fmt.Errorf("%s:%d: %w", file, n.Line, err))
+	}
+
+	var walk func(m *yaml.Node)
+	walk = func(m *yaml.Node) {
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			key, val := m.Content[i], m.Content[i+1]
+			switch key.Value {
+			case "scope":
+				if _, err := godot.ParseScope(val.Value); err != nil {
+					report(val, err)
+				}
+			case "exclude":
+				for _, n := range val.Content {
+					if _, err := regexp.Compile(n.Value); err != nil {
+						report(n, err)
+					}
+				}
+			case "paths":
+				for _, n := range val.Content {
+					if !validPattern(n.Value) {
+						report(n, fmt.Errorf("invalid path pattern '%s'", n.Value))
+					}
+				}
+			case "overrides":
+				for _, n := range val.Content {
+					walk(n)
+				}
+			}
+		}
+	}
+	for _, doc := range node.Content {
+		walk(doc)
+	}
+
+	return errors.Join(errs...)
+}
+
+var (
+	// yamlLine matches YAML errors with line numbers, e.g.
+	// "yaml: line 2: could not find expected ':'".
+	yamlLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+	// unknownField matches YAML errors about unknown fields.
+	unknownField = regexp.MustCompile(`^field (\S+) not found in type .*$`)
+)
+
+// configError converts a YAML error to a list of errors in the form
+// of "file:line: message".
+func configError(file string, err error) error {
+	msgs := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		msgs = typeErr.Errors
+	}
+
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		m := yamlLine.FindStringSubmatch(msg)
+		if m == nil {
+			errs[i] = fmt.Errorf("%s: %s", file, msg)
+			continue
+		}
+		msg = unknownField.ReplaceAllString(m[2], "unknown field '$1'")
+		errs[i] = fmt.Errorf("%s:%s: %s", file, m[1], msg)
+	}
+	return errors.Join(errs...)
+}
+
+// decode decodes top-level settings over the provided ones.
+func (cf *configFile) decode(settings *godot.Settings) error {
+	if cf.node.Kind == 0 {
+		// Empty file
+		return nil
+	}
+	if err := cf.node.Decode(settings); err != nil {
+		return fmt.Errorf("decode settings: %w", err)
+	}
+	return nil
+}
+
+// settings returns settings for the file. Config files are applied from
+// the farthest to the nearest, every config file applies its top-level
+// settings, and then all matching overrides. If the file is empty, only
+// top-level settings are applied.
+func (c config) settings(file string) (godot.Settings, error) {
+	settings := defaultSettings
+	for _, cf := range c {
+		if err := cf.decode(&settings); err != nil {
+			return godot.Settings{}, fmt.Errorf("parse config file %s: %w", cf.path, err)
+		}
+		if file == "" || len(cf.Overrides) == 0 {
+			continue
+		}
+		rel, err := relPath(filepath.Dir(cf.path), file)
+		if err != nil {
+			return godot.Settings{}, err
+		}
+		for _, o := range cf.Overrides {
+			if o.matches(rel) {
+				o.apply(&settings)
+			}
+		}
+	}
+	return settings, nil
+}
+
+// matches checks if the slash-separated path matches any of the override
+// patterns.
+func (o override) matches(file string) bool {
+	for _, p := range o.Paths {
+		if matchPath(p, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply changes the settings, that are set in the override.
+func (o override) apply(settings *godot.Settings) {
+	if o.Scope != nil {
+		settings.Scope = *o.Scope
+	}
+	if o.Exclude != nil {
+		settings.Exclude = o.Exclude
+	}
+	if o.Period != nil {
+		settings.Period = *o.Period
+	}
+	if o.Capital != nil {
+		settings.Capital = *o.Capital
+	}
+}
+
+// relPath returns a slash-separated path of the file relative
+// to the directory.
+func relPath(dir, file string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("get absolute path: %w", err)
+	}
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return "", fmt.Errorf("get absolute path: %w", err)
+	}
+	rel, err := filepath.Rel(absDir, absFile)
+	if err != nil {
+		return "", fmt.Errorf("get relative path: %w", err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// matchPath checks if the slash-separated path matches the glob pattern.
+// Besides the syntax of path.Match, pattern "**" matches zero or more
+// directories.
+func matchPath(pattern, name string) bool {
+	return matchParts(
+		strings.Split(strings.Trim(pattern, "/"), "/"),
+		strings.Split(name, "/"),
+	)
+}
+
+func matchParts(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// Try to match the rest of the pattern with every suffix
+			// of the path
+			for i := 0; i <= len(name); i++ {
+				if matchParts(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// validPattern checks the syntax of the path pattern.
+func validPattern(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+// runConfigCommand runs "godot config" subcommands:
+// "validate" checks config files for the path, and "print" prints
+// effective settings for the path.
+func runConfigCommand(w io.Writer, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing config command, expected validate or print")
+	}
+	cmd, args := args[0], args[1:]
+	if cmd != "validate" && cmd != "print" {
+		return fmt.Errorf("unknown config command '%s'", cmd)
+	}
+
+	var file, target string
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-c" || arg == "--config":
+			if len(args) < i+2 {
+				return fmt.Errorf("empty config file")
+			}
+			file = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--config="):
+			file = strings.TrimPrefix(arg, "--config=")
+		case strings.HasPrefix(arg, "-"):
+			return fmt.Errorf("unknown flag '%s'", arg)
+		case target != "":
+			return fmt.Errorf("too many arguments")
+		default:
+			target = arg
+		}
+	}
+	if target == "" {
+		target = "."
+	}
+
+	configs, err := newConfigLoader(file)
+	if err != nil {
+		return err
+	}
+	cfg, err := configs.chain(target)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "validate":
+		if len(cfg) == 0 {
+			if _, err := fmt.Fprintln(w, "No config files found"); err != nil {
+				return fmt.Errorf("write output: %w", err)
+			}
+			return nil
+		}
+		for _, cf := range cfg {
+			if _, err := fmt.Fprintf(w, "%s: OK\n", cf.path); err != nil {
+				return fmt.Errorf("write output: %w", err)
+			}
+		}
+		return nil
+	case "print":
+		// Overrides are applied only to files
+		var name string
+		if info, err := os.Stat(target); err == nil && !info.IsDir() {
+			name = target
+		}
+		settings, err := cfg.settings(name)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(settings)
+		if err != nil {
+			return fmt.Errorf("encode settings: %w", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tetafro/godot"
+)
+
+func TestConfigOverrides(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".godot.yaml")
+	data := `
+scope: all
+capital: true
+exclude: ['^todo:']
+overrides:
+  - paths: ['**/*_test.go', 'cmd/**']
+    scope: declarations
+    exclude: []
+  - paths: ['cmd/tool/*.go']
+    period: false
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	configs, err := newConfigLoader(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		file     string
+		settings godot.Settings
+	}{
+		{
+			file: filepath.Join(dir, "pkg", "main.go"),
+			settings: godot.Settings{
+				Scope:   godot.AllScope,
+				Exclude: []string{"^todo:"},
+				Period:  true,
+				Capital: true,
+			},
+		},
+		{
+			file: filepath.Join(dir, "pkg", "main_test.go"),
+			settings: godot.Settings{
+				Scope:   godot.DeclScope,
+				Exclude: []string{},
+				Period:  true,
+				Capital: true,
+			},
+		},
+		{
+			file: filepath.Join(dir, "cmd", "tool", "main.go"),
+			settings: godot.Settings{
+				Scope:   godot.DeclScope,
+				Exclude: []string{},
+				Period:  false,
+				Capital: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.file, func(t *testing.T) {
+			settings, err := configs.settings(tt.file)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(settings, tt.settings) {
+				t.Fatalf("Wrong settings\n  expected: %+v\n       got: %+v",
+					tt.settings, settings)
+			}
+		})
+	}
+
+	t.Run("file not found", func(t *testing.T) {
+		_, err := newConfigLoader(filepath.Join("testdata", "not-exists.yaml"))
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
+
+func TestConfigDiscovery(t *testing.T) {
+	// repo/.git
+	// repo/.godot.yaml          - capital: true, exclude: [x]
+	// repo/a/.godot.yaml        - scope: all, overrides for tests
+	// repo/a/b/.godot.yaml      - period: false
+	// repo/a/b/c/.godot.yaml    - empty
+	// repo/c/.godot.yaml        - root: true, scope: toplevel
+	// .godot.yaml               - outside of the repo, ignored
+	dir := t.TempDir()
+	files := map[string]string{
+		".godot.yaml":            "name: true\n",
+		"repo/.git/HEAD":         "",
+		"repo/.godot.yaml":       "capital: true\nexclude: [x]\n",
+		"repo/a/.godot.yaml":     "scope: all\noverrides:\n  - paths: ['**/*_test.go']\n    capital: false\n",
+		"repo/a/b/.godot.yaml":   "period: false\n",
+		"repo/a/b/c/.godot.yaml": "",
+		"repo/c/.godot.yaml":     "root: true\nscope: toplevel\n",
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	configs, err := newConfigLoader("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		file     string
+		settings godot.Settings
+	}{
+		{
+			file: "repo/main.go",
+			settings: godot.Settings{
+				Scope:   godot.DeclScope,
+				Exclude: []string{"x"},
+				Period:  true,
+				Capital: true,
+			},
+		},
+		{
+			file: "repo/a/main.go",
+			settings: godot.Settings{
+				Scope:   godot.AllScope,
+				Exclude: []string{"x"},
+				Period:  true,
+				Capital: true,
+			},
+		},
+		{
+			file: "repo/a/b/main_test.go",
+			settings: godot.Settings{
+				Scope:   godot.AllScope,
+				Exclude: []string{"x"},
+				Period:  false,
+				Capital: false,
+			},
+		},
+		{
+			file: "repo/a/b/c/d/main.go",
+			settings: godot.Settings{
+				Scope:   godot.AllScope,
+				Exclude: []string{"x"},
+				Period:  false,
+				Capital: true,
+			},
+		},
+		{
+			file: "repo/c/main.go",
+			settings: godot.Settings{
+				Scope:  godot.TopLevelScope,
+				Period: true,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.file, func(t *testing.T) {
+			settings, err := configs.settings(filepath.Join(dir, filepath.FromSlash(tt.file)))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(settings, tt.settings) {
+				t.Fatalf("Wrong settings\n  expected: %+v\n       got: %+v",
+					tt.settings, settings)
+			}
+		})
+	}
+}
+
+func TestReadConfigFile(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "valid",
+			data: "scope: all\nexclude: ['^todo:']\noverrides:\n  - paths: ['**/*_test.go']\n    period: false\n",
+		},
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name: "unknown field",
+			data: "period: true\nscopes: all\n",
+			err:  "{file}:2: unknown field 'scopes'",
+		},
+		{
+			name: "unknown override field",
+			data: "overrides:\n  - paths: ['*.go']\n    periods: false\n",
+			err:  "{file}:3: unknown field 'periods'",
+		},
+		{
+			name: "wrong type",
+			data: "period: [true]\n",
+			err:  "{file}:1: cannot unmarshal !!seq into bool",
+		},
+		{
+			name: "invalid values",
+			data: "scope: everything\nexclude: ['(']\noverrides:\n  - paths: ['a[']\n    scope: nothing\n",
+			err: "{file}:1: unknown scope 'everything'\n" +
+				"{file}:2: error parsing regexp: missing closing ): `(`\n" +
+				"{file}:4: invalid path pattern 'a['\n" +
+				"{file}:5: unknown scope 'nothing'",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".godot.yaml")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+			_, err := readConfigFile(path)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("Unexpected error: %v", err)
+			case tt.err != "" && err == nil:
+				t.Fatal("Expected error, got nil")
+			case tt.err != "" && err.Error() != strings.ReplaceAll(tt.err, "{file}", path):
+				t.Fatalf("Wrong error\n  expected: %s\n       got: %s", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestRunConfigCommand(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, ".godot.yaml")
+	if err := os.WriteFile(config, []byte("scope: all\nperiod: false\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("scopes: all\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		args   []string
+		output string
+		err    string
+	}{
+		{
+			name:   "validate",
+			args:   []string{"validate", dir},
+			output: config + ": OK\n",
+		},
+		{
+			name:   "validate config file",
+			args:   []string{"validate", "-c", config, dir},
+			output: config + ": OK\n",
+		},
+		{
+			name: "validate invalid config file",
+			args: []string{"validate", "--config=" + invalid, dir},
+			err:  invalid + ":1: unknown field 'scopes'",
+		},
+		{
+			name:   "print",
+			args:   []string{"print", dir},
+			output: "scope: all\n",
+		},
+		{
+			name: "no command",
+			args: []string{},
+			err:  "missing config command, expected validate or print",
+		},
+		{
+			name: "unknown command",
+			args: []string{"check", dir},
+			err:  "unknown config command 'check'",
+		},
+		{
+			name: "unknown flag",
+			args: []string{"print", "--format", dir},
+			err:  "unknown flag '--format'",
+		},
+		{
+			name: "too many arguments",
+			args: []string{"print", dir, dir},
+			err:  "too many arguments",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := runConfigCommand(&buf, tt.args)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("Unexpected error: %v", err)
+			case tt.err != "" && err == nil:
+				t.Fatal("Expected error, got nil")
+			case tt.err != "" && err.Error() != tt.err:
+				t.Fatalf("Wrong error\n  expected: %s\n       got: %s", tt.err, err)
+			case tt.err == "" && !strings.Contains(buf.String(), tt.output):
+				t.Fatalf("Wrong output\n  expected: %s\n       got: %s", tt.output, buf.String())
+			}
+		})
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{pattern: "main.go", path: "main.go", match: true},
+		{pattern: "*.go", path: "main.go", match: true},
+		{pattern: "*.go", path: "pkg/main.go", match: false},
+		{pattern: "internal/**", path: "internal/main.go", match: true},
+		{pattern: "internal/**", path: "internal/pkg/main.go", match: true},
+		{pattern: "internal/**", path: "pkg/internal/main.go", match: false},
+		{pattern: "**/*_test.go", path: "main_test.go", match: true},
+		{pattern: "**/*_test.go", path: "pkg/sub/main_test.go", match: true},
+		{pattern: "**/*_test.go", path: "pkg/main.go", match: false},
+		{pattern: "pkg/**/gen/*.go", path: "pkg/gen/main.go", match: true},
+		{pattern: "pkg/**/gen/*.go", path: "pkg/a/b/gen/main.go", match: true},
+		{pattern: "pkg/**/gen/*.go", path: "pkg/a/gen/b/main.go", match: false},
+		{pattern: "[", path: "main.go", match: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			if matchPath(tt.pattern, tt.path) != tt.match {
+				t.Fatalf("Wrong result\n  expected: %v\n       got: %v",
+					tt.match, !tt.match)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tetafro/godot"
+)
+
+// hunkHeader matches a header of a hunk in unified diff, e.g.
+// "@@ -10,2 +12,3 @@ func main() {".
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// changedLines is a set of changed lines for every file, where keys are
+// absolute file paths. A nil set of lines means that the whole file
+// is new.
+type changedLines map[string]map[int]bool
+
+// filter returns only issues on changed lines.
+func (c changedLines) filter(issues []godot.Issue) []godot.Issue {
+	var filtered []godot.Issue
+	for _, iss := range issues {
+		path, err := filepath.Abs(iss.Pos.Filename)
+		if err != nil {
+			continue
+		}
+		lines, ok := c[path]
+		if !ok {
+			continue
+		}
+		if lines == nil || lines[iss.Pos.Line] {
+			filtered = append(filtered, iss)
+		}
+	}
+	return filtered
+}
+
+// readDiff reads a unified diff from the file. Paths in the diff are
+// relative to the current directory.
+func readDiff(path string) (changedLines, error) {
+	f, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("open diff file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	return parseDiff(f, ".")
+}
+
+// gitDiff gets changes between the revision and the working tree,
+// including untracked files.
+func gitDiff(rev string) (changedLines, error) {
+	out, err := runGit("diff", "--relative", "--no-color", "--no-ext-diff", "-U0", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	changes, err := parseDiff(bytes.NewReader(out), ".")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err = runGit("ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range strings.Split(string(out), "\n") {
+		if file == "" {
+			continue
+		}
+		path, err := filepath.Abs(file)
+		if err != nil {
+			return nil, fmt.Errorf("get absolute path: %w", err)
+		}
+		changes[path] = nil
+	}
+
+	return changes, nil
+}
+
+func runGit(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("run git %s: %w: %s",
+			args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// parseDiff parses a unified diff and returns lines, that were added or
+// changed in the new version of files. Relative paths from the diff are
+// resolved using the directory.
+func parseDiff(r io.Reader, dir string) (changedLines, error) {
+	changes := changedLines{}
+
+	var lines map[int]bool   // changed lines of the current file
+	var line int             // current line number in the new file
+	var oldLeft, newLeft int // number of lines left in the current hunk
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		// Inside of a hunk
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if lines != nil {
+					lines[line] = true
+				}
+				line++
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				oldLeft--
+			case strings.HasPrefix(text, `\`):
+				// No newline at end of file
+			default:
+				line++
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			name := strings.TrimPrefix(text, "+++ ")
+			if i := strings.IndexByte(name, '\t'); i >= 0 {
+				name = name[:i] // trim timestamp
+			}
+			if name == "/dev/null" {
+				lines = nil // deleted file
+				continue
+			}
+			name = strings.TrimPrefix(name, "b/")
+			path, err := filepath.Abs(filepath.Join(dir, name))
+			if err != nil {
+				return nil, fmt.Errorf("get absolute path: %w", err)
+			}
+			lines = map[int]bool{}
+			changes[path] = lines
+		case strings.HasPrefix(text, "@@ "):
+			m := hunkHeader.FindStringSubmatch(text)
+			if m == nil {
+				return nil, fmt.Errorf("invalid hunk header '%s'", text)
+			}
+			oldLeft = atoiDefault(m[1], 1)
+			line = atoiDefault(m[2], 1)
+			newLeft = atoiDefault(m[3], 1)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read diff: %w", err)
+	}
+
+	return changes, nil
+}
+
+// atoiDefault converts string to integer, and returns the default value
+// for an empty string.
+func atoiDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tetafro/godot"
+)
+
+func TestParseDiff(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@
+ package main
+-// Old comment
++// New comment
++// Second line
+ func main() {}
+@@ -10 +11,0 @@ func main() {}
+-// Deleted comment
+@@ -20,0 +21,2 @@ func main() {}
++++ added line, that looks like a header
++// Added comment
+diff --git a/new.go b/new.go
+new file mode 100644
+--- /dev/null
++++ b/new.go
+@@ -0,0 +1 @@
++package main
+diff --git a/deleted.go b/deleted.go
+deleted file mode 100644
+--- a/deleted.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package main
+`
+	changes, err := parseDiff(strings.NewReader(diff), "dir")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	mainPath, _ := filepath.Abs(filepath.Join("dir", "main.go"))
+	newPath, _ := filepath.Abs(filepath.Join("dir", "new.go"))
+	expected := changedLines{
+		mainPath: {2: true, 3: true, 21: true, 22: true},
+		newPath:  {1: true},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("Wrong changes\n  expected: %v\n       got: %v", expected, changes)
+	}
+
+	t.Run("invalid hunk header", func(t *testing.T) {
+		_, err := parseDiff(strings.NewReader("+++ b/main.go\n@@ invalid @@\n"), ".")
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
+
+func TestChangedLinesFilter(t *testing.T) {
+	changed, _ := filepath.Abs("changed.go")
+	added, _ := filepath.Abs("added.go")
+	changes := changedLines{
+		changed: {2: true},
+		added:   nil,
+	}
+
+	issue := func(file string, line int) godot.Issue {
+		return godot.Issue{Pos: token.Position{Filename: file, Line: line}}
+	}
+	issues := []godot.Issue{
+		issue("changed.go", 1),
+		issue("changed.go", 2),
+		issue("added.go", 5),
+		issue("other.go", 2),
+	}
+	expected := []godot.Issue{
+		issue("changed.go", 2),
+		issue("added.go", 5),
+	}
+
+	if filtered := changes.filter(issues); !reflect.DeepEqual(filtered, expected) {
+		t.Fatalf("Wrong issues\n  expected: %v\n       got: %v", expected, filtered)
+	}
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"go/token"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tetafro/godot"
+)
+
+// Output formats.
+const (
+	textFormat       = "text"
+	jsonFormat       = "json"
+	sarifFormat      = "sarif"
+	checkstyleFormat = "checkstyle"
+	junitFormat      = "junit"
+)
+
+// reporter prints issues in a particular format.
+type reporter interface {
+	// report adds issues to the output.
+	report(issues []godot.Issue) error
+	// flush prints all the remaining output.
+	flush() error
+}
+
+func newReporter(format string, w io.Writer) (reporter, error) {
+	switch format {
+	case "", textFormat:
+		return &textReporter{w: w}, nil
+	case jsonFormat:
+		return &jsonReporter{collector{w: w}}, nil
+	case sarifFormat:
+		return &sarifReporter{collector{w: w}}, nil
+	case checkstyleFormat:
+		return &checkstyleReporter{collector{w: w}}, nil
+	case junitFormat:
+		return &junitReporter{collector{w: w}}, nil
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
+
+// textReporter prints issues as plain text lines as soon as they are
+// reported.
+type textReporter struct {
+	w io.Writer
+}
+
+func (r *textReporter) report(issues []godot.Issue) error {
+	for _, iss := range issues {
+		if _, err := fmt.Fprintf(r.w, "%s: %s\n", iss.Message, iss.Pos); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *textReporter) flush() error { return nil }
+
+// collector collects all issues to print them at once, which is required
+// for formats with a single document as output.
+type collector struct {
+	w      io.Writer
+	issues []godot.Issue
+}
+
+func (c *collector) report(issues []godot.Issue) error {
+	c.issues = append(c.issues, issues...)
+	return nil
+}
+
+// jsonReporter prints issues as a JSON array.
+type jsonReporter struct {
+	collector
+}
+
+type jsonIssue struct {
+	Rule        string     `json:"rule"`
+	Message     string     `json:"message"`
+	Filename    string     `json:"filename"`
+	Line        int        `json:"line"`
+	Column      int        `json:"column"`
+	EndLine     int        `json:"end_line"`
+	EndColumn   int        `json:"end_column"`
+	Replacement string     `json:"replacement,omitempty"`
+	Edits       []jsonEdit `json:"edits,omitempty"`
+}
+
+type jsonEdit struct {
+	Offset    int    `json:"offset"`
+	EndOffset int    `json:"end_offset"`
+	NewText   string `json:"new_text"`
+}
+
+func (r *jsonReporter) flush() error {
+	out := make([]jsonIssue, len(r.issues))
+	for i, iss := range r.issues {
+		end := issueEnd(iss)
+		out[i] = jsonIssue{
+			Rule:        iss.Rule,
+			Message:     iss.Message,
+			Filename:    iss.Pos.Filename,
+			Line:        iss.Pos.Line,
+			Column:      iss.Pos.Column,
+			EndLine:     end.Line,
+			EndColumn:   end.Column,
+			Replacement: iss.Replacement,
+		}
+		for _, e := range iss.Edits {
+			out[i].Edits = append(out[i].Edits, jsonEdit{
+				Offset:    e.Pos.Offset,
+				EndOffset: e.End.Offset,
+				NewText:   e.NewText,
+			})
+		}
+	}
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
+
+// sarifReporter prints issues as a SARIF 2.1.0 log.
+type sarifReporter struct {
+	collector
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifByteRegion struct {
+	ByteOffset int `json:"byteOffset"`
+	ByteLength int `json:"byteLength"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage          `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifByteRegion `json:"deletedRegion"`
+	InsertedContent sarifMessage    `json:"insertedContent"`
+}
+
+func (r *sarifReporter) flush() error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "godot",
+			Version:        version,
+			InformationURI: "https://github.com/tetafro/godot",
+			Rules:          []sarifRule{},
+		}},
+		Results: make([]sarifResult, 0, len(r.issues)),
+	}
+
+	rules := map[string]bool{}
+	for _, iss := range r.issues {
+		if !rules[iss.Rule] {
+			rules[iss.Rule] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               iss.Rule,
+				ShortDescription: sarifMessage{Text: iss.Message},
+			})
+		}
+
+		uri := fileURI(iss.Pos.Filename)
+		end := issueEnd(iss)
+		res := sarifResult{
+			RuleID:  iss.Rule,
+			Level:   "warning",
+			Message: sarifMessage{Text: iss.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: uri},
+				Region: sarifRegion{
+					StartLine:   iss.Pos.Line,
+					StartColumn: iss.Pos.Column,
+					EndLine:     end.Line,
+					EndColumn:   end.Column,
+				},
+			}}},
+		}
+		if len(iss.Edits) > 0 {
+			change := sarifArtifactChange{
+				ArtifactLocation: sarifArtifactLocation{URI: uri},
+			}
+			for _, e := range iss.Edits {
+				change.Replacements = append(change.Replacements, sarifReplacement{
+					DeletedRegion: sarifByteRegion{
+						ByteOffset: e.Pos.Offset,
+						ByteLength: e.End.Offset - e.Pos.Offset,
+					},
+					InsertedContent: sarifMessage{Text: e.NewText},
+				})
+			}
+			res.Fixes = []sarifFix{{
+				Description:     sarifMessage{Text: "Fix comment"},
+				ArtifactChanges: []sarifArtifactChange{change},
+			}}
+		}
+		run.Results = append(run.Results, res)
+	}
+
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+	if err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
+
+// fileURI converts a file path to URI. Relative paths are kept relative.
+func fileURI(path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	if !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path // Windows paths
+	}
+	return u.String()
+}
+
+// checkstyleReporter prints issues as a Checkstyle XML report.
+type checkstyleReporter struct {
+	collector
+}
+
+type checkstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+func (r *checkstyleReporter) flush() error {
+	out := checkstyleOutput{Version: "5.0"}
+	for _, file := range groupByFile(r.issues) {
+		f := checkstyleFile{Name: file[0].Pos.Filename}
+		for _, iss := range file {
+			f.Errors = append(f.Errors, checkstyleError{
+				Line:     iss.Pos.Line,
+				Column:   iss.Pos.Column,
+				Severity: "warning",
+				Message:  iss.Message,
+				Source:   "godot." + iss.Rule,
+			})
+		}
+		out.Files = append(out.Files, f)
+	}
+	return writeXML(r.w, out)
+}
+
+// junitReporter prints issues as a JUnit XML report, where every file is
+// a test suite, and every issue is a failed test case.
+type junitReporter struct {
+	collector
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *junitReporter) flush() error {
+	out := junitTestSuites{Suites: []junitTestSuite{}}
+	for _, file := range groupByFile(r.issues) {
+		suite := junitTestSuite{
+			Name:     file[0].Pos.Filename,
+			Tests:    len(file),
+			Failures: len(file),
+		}
+		for _, iss := range file {
+			text := fmt.Sprintf("%s: %s", iss.Message, iss.Pos)
+			if iss.Replacement != "" {
+				text += "\nReplacement: " + iss.Replacement
+			}
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      iss.Pos.String(),
+				ClassName: iss.Rule,
+				Failure: junitFailure{
+					Message: iss.Message,
+					Type:    iss.Rule,
+					Text:    text,
+				},
+			})
+		}
+		out.Suites = append(out.Suites, suite)
+	}
+	return writeXML(r.w, out)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
+
+// groupByFile groups issues by filename keeping the order of files.
+func groupByFile(issues []godot.Issue) [][]godot.Issue {
+	var groups [][]godot.Issue
+	index := map[string]int{}
+	for _, iss := range issues {
+		i, ok := index[iss.Pos.Filename]
+		if !ok {
+			i = len(groups)
+			index[iss.Pos.Filename] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], iss)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i][0].Pos.Filename < groups[j][0].Pos.Filename
+	})
+	return groups
+}
+
+// issueEnd returns the end position of the issue, which is the end of
+// its last edit, or the issue position itself if there are no edits.
+func issueEnd(iss godot.Issue) token.Position {
+	if len(iss.Edits) == 0 {
+		return iss.Pos
+	}
+	return iss.Edits[len(iss.Edits)-1].End
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tetafro/godot"
+)
+
+// testIssues returns issues of two files: one with an insertion, one with
+// a replacement and one without edits.
+func testIssues() []godot.Issue {
+	pos := func(file string, offset, line, column int) token.Position {
+		return token.Position{Filename: file, Offset: offset, Line: line, Column: column}
+	}
+	return []godot.Issue{
+		{
+			Pos:         pos("b.go", 20, 3, 9),
+			Rule:        godot.PeriodRule,
+			Message:     "Comment should end in a period",
+			Replacement: "// Foo x.",
+			Edits: []godot.TextEdit{{
+				Pos:     pos("b.go", 28, 3, 9),
+				End:     pos("b.go", 28, 3, 9),
+				NewText: ".",
+			}},
+		},
+		{
+			Pos:         pos("a.go", 0, 1, 4),
+			Rule:        godot.CapitalRule,
+			Message:     "Sentence should start with a capital letter",
+			Replacement: "// Foo.",
+			Edits: []godot.TextEdit{{
+				Pos:     pos("a.go", 3, 1, 4),
+				End:     pos("a.go", 4, 1, 5),
+				NewText: "F",
+			}},
+		},
+		{
+			Pos:     pos("b.go", 40, 5, 1),
+			Rule:    godot.MissingDocRule,
+			Message: "Exported declaration should have a doc comment",
+		},
+	}
+}
+
+// runReporter reports the issues in the format, and returns the output.
+func runReporter(t *testing.T, format string, issues []godot.Issue) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	r, err := newReporter(format, &buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Issues are reported by files
+	for _, file := range [][]godot.Issue{issues[:1], issues[1:]} {
+		if err := r.report(file); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := r.flush(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestNewReporter(t *testing.T) {
+	for _, format := range []string{"", textFormat, jsonFormat, sarifFormat, checkstyleFormat, junitFormat} {
+		if _, err := newReporter(format, &bytes.Buffer{}); err != nil {
+			t.Fatalf("Unexpected error for format '%s': %v", format, err)
+		}
+	}
+	if _, err := newReporter("yaml", &bytes.Buffer{}); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestTextReporter(t *testing.T) {
+	out := runReporter(t, textFormat, testIssues())
+	expected := "Comment should end in a period: b.go:3:9\n" +
+		"Sentence should start with a capital letter: a.go:1:4\n" +
+		"Exported declaration should have a doc comment: b.go:5:1\n"
+	if string(out) != expected {
+		t.Fatalf("Wrong output\n  expected: %q\n       got: %q", expected, out)
+	}
+}
+
+func TestJSONReporter(t *testing.T) {
+	var got []jsonIssue
+	if err := json.Unmarshal(runReporter(t, jsonFormat, testIssues()), &got); err != nil {
+		t.Fatalf("Failed to decode output: %v", err)
+	}
+
+	expected := []jsonIssue{
+		{
+			Rule:        godot.PeriodRule,
+			Message:     "Comment should end in a period",
+			Filename:    "b.go",
+			Line:        3,
+			Column:      9,
+			EndLine:     3,
+			EndColumn:   9,
+			Replacement: "// Foo x.",
+			Edits:       []jsonEdit{{Offset: 28, EndOffset: 28, NewText: "."}},
+		},
+		{
+			Rule:        godot.CapitalRule,
+			Message:     "Sentence should start with a capital letter",
+			Filename:    "a.go",
+			Line:        1,
+			Column:      4,
+			EndLine:     1,
+			EndColumn:   5,
+			Replacement: "// Foo.",
+			Edits:       []jsonEdit{{Offset: 3, EndOffset: 4, NewText: "F"}},
+		},
+		{
+			Rule:      godot.MissingDocRule,
+			Message:   "Exported declaration should have a doc comment",
+			Filename:  "b.go",
+			Line:      5,
+			Column:    1,
+			EndLine:   5,
+			EndColumn: 1,
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Wrong output\n  expected: %+v\n       got: %+v", expected, got)
+	}
+}
+
+func TestJSONReporterNoIssues(t *testing.T) {
+	var buf bytes.Buffer
+	r := &jsonReporter{collector{w: &buf}}
+	if err := r.flush(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Fatalf("Wrong output: %q", buf.String())
+	}
+}
+
+func TestSARIFReporter(t *testing.T) {
+	var got sarifLog
+	if err := json.Unmarshal(runReporter(t, sarifFormat, testIssues()), &got); err != nil {
+		t.Fatalf("Failed to decode output: %v", err)
+	}
+
+	if got.Version != "2.1.0" || len(got.Runs) != 1 {
+		t.Fatalf("Wrong log: %+v", got)
+	}
+	run := got.Runs[0]
+	if run.Tool.Driver.Name != "godot" {
+		t.Fatalf("Wrong tool name: %s", run.Tool.Driver.Name)
+	}
+	var rules []string
+	for _, r := range run.Tool.Driver.Rules {
+		rules = append(rules, r.ID)
+	}
+	expectedRules := []string{godot.PeriodRule, godot.CapitalRule, godot.MissingDocRule}
+	if !reflect.DeepEqual(rules, expectedRules) {
+		t.Fatalf("Wrong rules\n  expected: %v\n       got: %v", expectedRules, rules)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("Wrong number of results\n  expected: 3\n       got: %d", len(run.Results))
+	}
+
+	// Issue with a replacement
+	res := run.Results[1]
+	loc := res.Locations[0].PhysicalLocation
+	expectedRegion := sarifRegion{StartLine: 1, StartColumn: 4, EndLine: 1, EndColumn: 5}
+	if res.RuleID != godot.CapitalRule || loc.ArtifactLocation.URI != "a.go" || loc.Region != expectedRegion {
+		t.Fatalf("Wrong result: %+v", res)
+	}
+	if len(res.Fixes) != 1 || len(res.Fixes[0].ArtifactChanges) != 1 {
+		t.Fatalf("Wrong fixes: %+v", res.Fixes)
+	}
+	expectedReplacements := []sarifReplacement{{
+		DeletedRegion:   sarifByteRegion{ByteOffset: 3, ByteLength: 1},
+		InsertedContent: sarifMessage{Text: "F"},
+	}}
+	if r := res.Fixes[0].ArtifactChanges[0].Replacements; !reflect.DeepEqual(r, expectedReplacements) {
+		t.Fatalf("Wrong replacements\n  expected: %+v\n       got: %+v", expectedReplacements, r)
+	}
+
+	// Issue without edits
+	if res := run.Results[2]; len(res.Fixes) != 0 {
+		t.Fatalf("Unexpected fixes: %+v", res.Fixes)
+	}
+}
+
+func TestCheckstyleReporter(t *testing.T) {
+	out := runReporter(t, checkstyleFormat, testIssues())
+	if !bytes.HasPrefix(out, []byte(xml.Header)) {
+		t.Fatalf("No XML header in output: %s", out)
+	}
+	var got checkstyleOutput
+	if err := xml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("Failed to decode output: %v", err)
+	}
+
+	expected := []checkstyleFile{
+		{
+			Name: "a.go",
+			Errors: []checkstyleError{{
+				Line: 1, Column: 4, Severity: "warning",
+				Message: "Sentence should start with a capital letter",
+				Source:  "godot.capital",
+			}},
+		},
+		{
+			Name: "b.go",
+			Errors: []checkstyleError{
+				{
+					Line: 3, Column: 9, Severity: "warning",
+					Message: "Comment should end in a period",
+					Source:  "godot.period",
+				},
+				{
+					Line: 5, Column: 1, Severity: "warning",
+					Message: "Exported declaration should have a doc comment",
+					Source:  "godot.missing-doc",
+				},
+			},
+		},
+	}
+	if got.Version != "5.0" || !reflect.DeepEqual(got.Files, expected) {
+		t.Fatalf("Wrong output\n  expected: %+v\n       got: %+v", expected, got.Files)
+	}
+}
+
+func TestJUnitReporter(t *testing.T) {
+	out := runReporter(t, junitFormat, testIssues())
+	var got junitTestSuites
+	if err := xml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("Failed to decode output: %v", err)
+	}
+
+	if len(got.Suites) != 2 {
+		t.Fatalf("Wrong number of suites\n  expected: 2\n       got: %d", len(got.Suites))
+	}
+	for i, expected := range []struct {
+		name  string
+		tests int
+	}{{name: "a.go", tests: 1}, {name: "b.go", tests: 2}} {
+		suite := got.Suites[i]
+		if suite.Name != expected.name || suite.Tests != expected.tests ||
+			suite.Failures != expected.tests || len(suite.Cases) != expected.tests {
+			t.Fatalf("Wrong suite: %+v", suite)
+		}
+	}
+
+	expectedCase := junitTestCase{
+		Name:      "b.go:3:9",
+		ClassName: godot.PeriodRule,
+		Failure: junitFailure{
+			Message: "Comment should end in a period",
+			Type:    godot.PeriodRule,
+			Text:    "Comment should end in a period: b.go:3:9\nReplacement: // Foo x.",
+		},
+	}
+	if c := got.Suites[1].Cases[0]; c != expectedCase {
+		t.Fatalf("Wrong test case\n  expected: %+v\n       got: %+v", expectedCase, c)
+	}
+}
+
+func TestGroupByFile(t *testing.T) {
+	issues := testIssues()
+	groups := groupByFile(issues)
+
+	expected := [][]godot.Issue{
+		{issues[1]},
+		{issues[0], issues[2]},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Fatalf("Wrong groups\n  expected: %v\n       got: %v", expected, groups)
+	}
+	if groups := groupByFile(nil); len(groups) != 0 {
+		t.Fatalf("Unexpected groups: %v", groups)
+	}
+}
+
+func TestFileURI(t *testing.T) {
+	testCases := []struct {
+		path string
+		uri  string
+	}{
+		{path: "main.go", uri: "main.go"},
+		{path: "pkg/main.go", uri: "pkg/main.go"},
+		{path: "/home/user/main.go", uri: "file:///home/user/main.go"},
+		{path: "/home/user/my project/main.go", uri: "file:///home/user/my%20project/main.go"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.path, func(t *testing.T) {
+			if uri := fileURI(tt.path); uri != tt.uri {
+				t.Fatalf("Wrong URI\n  expected: %s\n       got: %s", tt.uri, uri)
+			}
+		})
+	}
+}
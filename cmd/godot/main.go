@@ -9,10 +9,11 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/tetafro/godot"
-	"go.yaml.in/yaml/v3"
 )
 
 // version is the application version. It is set to the latest git tag in CI.
@@ -27,11 +28,31 @@ var defaultSettings = godot.Settings{
 }
 
 const usage = `Usage:
-    godot [OPTION] [FILES]
+    godot [OPTION] [FILES|PACKAGES]
+    godot config validate|print [-c CONFIG] [PATH]
+Files and directories are walked recursively, packages are set as
+patterns, e.g. ./..., ./pkg/..., github.com/user/project/pkg.
+Config commands validate config files, or print effective settings
+for the path (current directory by default).
 Options:
     -c, --config    path to config file
     -f, --fix       fix issues, and print fixed version to stdout
     -w, --write     fix issues, and write result to original file
+    -j, --jobs      number of files processed in parallel
+                    (default is the number of CPUs)
+        --tags      comma-separated list of build tags used for resolving
+                    packages
+        --format    output format: text (default), json, sarif,
+                    checkstyle, junit
+        --new-from-rev
+                    report only issues on lines changed since
+                    the git revision
+        --diff      report only issues on lines changed in the unified
+                    diff file
+        --baseline  report only issues, that are not in the baseline file
+        --write-baseline
+                    write all current issues to the baseline file,
+                    --baseline is ignored
     -h, --help      show this message
     -v, --version   show version`
 
@@ -39,6 +60,13 @@ type arguments struct {
 	config  string
 	fix     bool
 	write   bool
+	jobs    int
+	tags    string
+	format  string
+	rev     string
+	diff    string
+	base    string
+	newBase string
 	files   []string
 	help    bool
 	version bool
@@ -46,6 +74,13 @@ type arguments struct {
 
 //nolint:funlen
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		if err := runConfigCommand(os.Stdout, os.Args[2:]); err != nil {
+			fatalf("Error: %v", err)
+		}
+		return
+	}
+
 	// Read command line arguments
 	args, err := readArgs()
 	if err != nil {
@@ -63,52 +98,133 @@ func main() {
 	}
 
 	// Get settings from file or get defaults
-	settings, err := getSettings(args.config)
+	configs, err := newConfigLoader(args.config)
 	if err != nil {
 		fatalf("Error: %v", err)
 	}
 
-	// Parse files
-	var paths []string
-	var files []*ast.File
-	fset := token.NewFileSet()
-	for _, path := range args.files {
-		if _, err := os.Stat(path); os.IsNotExist(err) {
-			fatalf("Path '%s' does not exist", path)
-		}
-		for f := range findFiles(path) {
-			file, err := parser.ParseFile(fset, f, nil, parser.ParseComments)
-			if err != nil {
-				fatalf("Failed to parse file '%s': %v", path, err)
-			}
-			files = append(files, file)
-			paths = append(paths, f)
+	out, err := newReporter(args.format, os.Stdout)
+	if err != nil {
+		fatalf("Error: %v", err)
+	}
+
+	// Get changed lines to filter issues
+	var changes changedLines
+	switch {
+	case args.rev != "":
+		changes, err = gitDiff(args.rev)
+	case args.diff != "":
+		changes, err = readDiff(args.diff)
+	}
+	if err != nil {
+		fatalf("Failed to get changes: %v", err)
+	}
+
+	// Get known issues to filter them. A new baseline contains all current
+	// issues, so the old one is not used then.
+	var known *baselineFilter
+	if args.base != "" && args.newBase == "" {
+		known, err = readBaseline(args.base)
+		if err != nil {
+			fatalf("Failed to read baseline: %v", err)
 		}
 	}
+	var newBase *baselineWriter
+	if args.newBase != "" {
+		newBase = newBaselineWriter()
+	}
 
-	// Run linter
-	for i := range files {
+	// Process files in parallel, every file is parsed and linted
+	// independently
+	process := func(path string) result {
+		res := result{path: path, fset: token.NewFileSet()}
+		settings, err := configs.settings(path)
+		if err != nil {
+			res.err = fmt.Errorf("get settings for file '%s': %w", path, err)
+			return res
+		}
+		res.settings = settings
+		res.file, res.err = parser.ParseFile(res.fset, path, nil, parser.ParseComments)
+		if res.err != nil {
+			res.err = fmt.Errorf("parse file '%s': %w", path, res.err)
+			return res
+		}
 		switch {
 		case args.fix:
-			fixed, err := godot.Fix(paths[i], files[i], fset, settings)
-			if err != nil {
-				fatalf("Failed to autofix file '%s': %v", paths[i], err)
+			res.fixed, res.err = godot.Fix(path, res.file, res.fset, settings)
+			if res.err != nil {
+				res.err = fmt.Errorf("autofix file '%s': %w", path, res.err)
 			}
-			fmt.Print(string(fixed))
 		case args.write:
-			if err := godot.Replace(paths[i], files[i], fset, settings); err != nil {
-				fatalf("Failed to rewrite file '%s': %v", paths[i], err)
+			res.err = godot.Replace(path, res.file, res.fset, settings)
+			if res.err != nil {
+				res.err = fmt.Errorf("rewrite file '%s': %w", path, res.err)
 			}
 		default:
-			issues, err := godot.Run(files[i], fset, settings)
-			if err != nil {
-				fatalf("Failed to run linter on file '%s': %v", paths[i], err)
+			res.issues, res.err = godot.Run(res.file, res.fset, settings)
+			if res.err != nil {
+				res.err = fmt.Errorf("run linter on file '%s': %w", path, res.err)
 			}
+		}
+		return res
+	}
+	report := func(file *ast.File, fset *token.FileSet, issues []godot.Issue) {
+		if changes != nil {
+			issues = changes.filter(issues)
+		}
+		if known != nil {
+			issues = known.filter(file, fset, issues)
+		}
+		if newBase != nil {
+			newBase.add(file, fset, issues)
+			return
+		}
+		if err := out.report(issues); err != nil {
+			fatalf("Error: %v", err)
+		}
+	}
+	pkgs := newPackageFiles()
+	handle := func(res result) {
+		if res.err != nil {
+			fatalf("Failed to %v", res.err)
+		}
+		if args.fix {
+			fmt.Print(string(res.fixed))
+			return
+		}
+		if (res.settings.PackageDoc || res.settings.DocLinks) && !args.write {
+			pkgs.add(res.path, res.file, res.settings)
+		}
+		report(res.file, res.fset, res.issues)
+	}
+	processFiles(findAllFiles(args.files, args.tags), args.jobs, process, handle)
+
+	// Run package level checks, when all files are known
+	for _, key := range pkgs.keys {
+		fset, files, issues, err := lintPackage(key, pkgs.files[key], args.tags, pkgs.settings[key])
+		if err != nil {
+			fatalf("Failed to %v", err)
+		}
+		for _, file := range files {
+			var fileIssues []godot.Issue
 			for _, iss := range issues {
-				fmt.Printf("%s: %s\n", iss.Message, iss.Pos)
+				if iss.Pos.Filename == fset.Position(file.Pos()).Filename {
+					fileIssues = append(fileIssues, iss)
+				}
 			}
+			report(file, fset, fileIssues)
 		}
 	}
+
+	if newBase != nil {
+		if err := newBase.write(args.newBase); err != nil {
+			fatalf("Failed to write baseline: %v", err)
+		}
+		return
+	}
+	if err := out.flush(); err != nil {
+		fatalf("Error: %v", err)
+	}
 }
 
 func readArgs() (args arguments, err error) {
@@ -149,11 +265,71 @@ func readArgs() (args arguments, err error) {
 			args.fix = true
 		case "-w", "--write":
 			args.write = true
+		case "-j", "--jobs":
+			// Next argument must be number of jobs value
+			if len(input) < i+2 {
+				return arguments{}, fmt.Errorf("empty number of jobs")
+			}
+			args.jobs, err = strconv.Atoi(input[i+1])
+			if err != nil || args.jobs < 1 {
+				return arguments{}, fmt.Errorf("invalid number of jobs '%s'", input[i+1])
+			}
+			i++
+		case "--tags":
+			// Next argument must be build tags value
+			if len(input) < i+2 {
+				return arguments{}, fmt.Errorf("empty build tags")
+			}
+			args.tags = input[i+1]
+			i++
+		case "--format":
+			// Next argument must be format value
+			if len(input) < i+2 {
+				return arguments{}, fmt.Errorf("empty format")
+			}
+			args.format = input[i+1]
+			i++
+		case "--new-from-rev":
+			// Next argument must be revision value
+			if len(input) < i+2 {
+				return arguments{}, fmt.Errorf("empty revision")
+			}
+			args.rev = input[i+1]
+			i++
+		case "--baseline":
+			// Next argument must be baseline file value
+			if len(input) < i+2 {
+				return arguments{}, fmt.Errorf("empty baseline file")
+			}
+			args.base = input[i+1]
+			i++
+		case "--write-baseline":
+			// Next argument must be baseline file value
+			if len(input) < i+2 {
+				return arguments{}, fmt.Errorf("empty baseline file")
+			}
+			args.newBase = input[i+1]
+			i++
+		case "--diff":
+			// Next argument must be diff file value
+			if len(input) < i+2 {
+				return arguments{}, fmt.Errorf("empty diff file")
+			}
+			args.diff = input[i+1]
+			i++
 		default:
 			return arguments{}, fmt.Errorf("unknown flag '%s'", arg)
 		}
 	}
 
+	if args.jobs == 0 {
+		args.jobs = runtime.GOMAXPROCS(0)
+	}
+
+	if args.rev != "" && args.diff != "" {
+		return arguments{}, fmt.Errorf("--new-from-rev and --diff cannot be used together")
+	}
+
 	if !args.help && !args.version && len(args.files) == 0 {
 		return arguments{}, fmt.Errorf("files list is empty")
 	}
@@ -161,29 +337,34 @@ func readArgs() (args arguments, err error) {
 	return args, nil
 }
 
-func getSettings(file string) (godot.Settings, error) {
-	settings := defaultSettings
+// findAllFiles finds Go files in all the paths. Paths can be files,
+// directories or package patterns.
+func findAllFiles(paths []string, tags string) chan string {
+	out := make(chan string)
 
-	if file == "" {
-		// Check default config file
-		if _, err := os.Stat(defaultConfigFile); os.IsNotExist(err) {
-			return settings, nil
+	go func() {
+		defer close(out)
+		for _, path := range paths {
+			if isPattern(path) {
+				files, err := loadPackageFiles([]string{path}, tags)
+				if err != nil {
+					fatalf("Failed to resolve packages '%s': %v", path, err)
+				}
+				for _, f := range files {
+					out <- f
+				}
+				continue
+			}
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				fatalf("Path '%s' does not exist", path)
+			}
+			for f := range findFiles(path) {
+				out <- f
+			}
 		}
-		file = defaultConfigFile
-	}
+	}()
 
-	data, err := os.ReadFile(file) //nolint:gosec
-	if err != nil {
-		return godot.Settings{}, fmt.Errorf(
-			"read config file %s: %w", defaultConfigFile, err,
-		)
-	}
-	if err := yaml.Unmarshal(data, &settings); err != nil {
-		return godot.Settings{}, fmt.Errorf(
-			"parse config file %s: %w", defaultConfigFile, err,
-		)
-	}
-	return settings, nil
+	return out
 }
 
 func findFiles(root string) chan string {
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tetafro/godot"
+	"golang.org/x/tools/go/packages"
+)
+
+// isPattern checks if the argument is a package pattern (e.g. "./...")
+// or an import path rather than a path to a file or a directory. Arguments,
+// that look like paths (e.g. "main.go" or "./pkg"), are never import paths,
+// even if they don't exist.
+func isPattern(arg string) bool {
+	if strings.Contains(arg, "...") {
+		return true
+	}
+	if _, err := os.Stat(arg); !os.IsNotExist(err) {
+		return false
+	}
+	switch {
+	case strings.HasSuffix(arg, ".go"),
+		arg == ".", arg == "..",
+		strings.HasPrefix(arg, "./"), strings.HasPrefix(arg, "../"),
+		filepath.IsAbs(arg):
+		return false
+	}
+	return true
+}
+
+// loadPackageFiles resolves package patterns the same way as `go build`
+// does, and returns Go files of the packages including test files.
+// Build tags and GOFLAGS environment variable are taken into account.
+func loadPackageFiles(patterns []string, tags string) ([]string, error) {
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles,
+		Tests: true,
+	}
+	if tags != "" {
+		cfg.BuildFlags = []string{"-tags=" + tags}
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no packages matching %s", strings.Join(patterns, " "))
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("get working directory: %w", err)
+	}
+
+	// Test variants of packages contain the same files, so skip duplicates
+	var files []string
+	seen := map[string]bool{}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, errors.New(pkg.Errors[0].Msg)
+		}
+		for _, f := range pkg.GoFiles {
+			if seen[f] || !strings.HasSuffix(f, ".go") {
+				continue
+			}
+			seen[f] = true
+			// Keep paths relative to the current directory if possible
+			if rel, err := filepath.Rel(wd, f); err == nil && !strings.HasPrefix(rel, "..") {
+				f = rel
+			}
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// packageFiles groups linted files by packages for package level checks.
+// Packages are identified by the directory and the package name.
+type packageFiles struct {
+	keys     []packageKey
+	files    map[packageKey][]string
+	settings map[packageKey]godot.Settings
+}
+
+type packageKey struct {
+	dir  string
+	name string
+}
+
+func newPackageFiles() *packageFiles {
+	return &packageFiles{
+		files:    map[packageKey][]string{},
+		settings: map[packageKey]godot.Settings{},
+	}
+}
+
+// add adds the file to its package. Settings of the first file are used
+// for the whole package.
+func (p *packageFiles) add(path string, file *ast.File, settings godot.Settings) {
+	key := packageKey{dir: filepath.Dir(path), name: file.Name.Name}
+	if _, ok := p.files[key]; !ok {
+		p.keys = append(p.keys, key)
+		p.settings[key] = settings
+	}
+	p.files[key] = append(p.files[key], path)
+}
+
+// lintPackage runs package level checks on files of the package. Files
+// are parsed using the same file set. Other files of the package from the
+// same directory are checked too, since the package comment or the doc link
+// target may be there, but only issues of the given files are returned.
+func lintPackage(key packageKey, paths []string, tags string, settings godot.Settings) (*token.FileSet, []*ast.File, []godot.Issue, error) {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, len(paths))
+	for i, path := range paths {
+		var err error
+		files[i], err = parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("parse file '%s': %w", path, err)
+		}
+	}
+	others, err := otherPackageFiles(fset, key, paths, tags)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	issues, err := godot.RunPackage(append(others, files...), fset, settings)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("run linter on package '%s': %w", key.dir, err)
+	}
+	return fset, files, issues, nil
+}
+
+// otherPackageFiles parses files of the package from its directory, that
+// are not in the list. Files excluded by build constraints, and files that
+// can't be parsed are skipped, they are not linted anyway.
+func otherPackageFiles(fset *token.FileSet, key packageKey, paths []string, tags string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(key.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory '%s': %w", key.dir, err)
+	}
+	ctx := build.Default
+	if tags != "" {
+		ctx.BuildTags = strings.Split(tags, ",")
+	}
+	known := map[string]bool{}
+	for _, path := range paths {
+		known[filepath.Clean(path)] = true
+	}
+	var files []*ast.File
+	for _, e := range entries {
+		path := filepath.Join(key.dir, e.Name())
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || known[path] {
+			continue
+		}
+		if ok, err := ctx.MatchFile(key.dir, e.Name()); err != nil || !ok {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil || file.Name.Name != key.name {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
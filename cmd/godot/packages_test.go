@@ -0,0 +1,138 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/tetafro/godot"
+)
+
+func TestLoadPackageFiles(t *testing.T) {
+	t.Run("pattern", func(t *testing.T) {
+		files, err := loadPackageFiles([]string{"../../..."}, "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		root, err := filepath.Abs("../..")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, f := range []string{
+			filepath.Join(root, "godot.go"),
+			filepath.Join(root, "godot_test.go"),
+			"main.go",
+		} {
+			if !slices.Contains(files, f) {
+				t.Fatalf("File %s not found in %v", f, files)
+			}
+		}
+		// Test data is not a part of any package
+		for _, f := range files {
+			if filepath.Dir(f) == filepath.Join(root, "testdata", "check") {
+				t.Fatalf("Unexpected file %s", f)
+			}
+		}
+	})
+
+	t.Run("unknown package", func(t *testing.T) {
+		_, err := loadPackageFiles([]string{"github.com/tetafro/godot/not-exists"}, "")
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+}
+
+func TestIsPattern(t *testing.T) {
+	testCases := []struct {
+		arg     string
+		pattern bool
+	}{
+		{arg: "./...", pattern: true},
+		{arg: "github.com/tetafro/godot", pattern: true},
+		{arg: ".", pattern: false},
+		{arg: "main.go", pattern: false},
+		{arg: "mian.go", pattern: false},
+		{arg: "./not-exists", pattern: false},
+		{arg: "../not-exists", pattern: false},
+		{arg: "/not-exists", pattern: false},
+		{arg: "not-exists/pkg.go", pattern: false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.arg, func(t *testing.T) {
+			if isPattern(tt.arg) != tt.pattern {
+				t.Fatalf("Wrong result")
+			}
+		})
+	}
+}
+
+func TestLintPackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go":        "package p\n\n// Foo calls [Bar].\nfunc Foo() {}\n",
+		"a_tagged.go": "//go:build foo\n\n// Package p is tagged.\npackage p\n",
+		"b.go":        "package p\n\n// Bar does bar.\nfunc Bar() {}\n",
+		"doc.go":      "// Package p does p.\npackage p\n",
+		"gen.go":      "//go:build ignore\n\n// Package p is generated.\npackage p\n",
+		"p_test.go":   "package p_test\n",
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+	settings := godot.Settings{PackageDoc: true, DocLinks: true}
+
+	t.Run("package comment in other file", func(t *testing.T) {
+		key := packageKey{dir: dir, name: "p"}
+		_, parsed, issues, err := lintPackage(key, []string{filepath.Join(dir, "a.go")}, "", settings)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(parsed) != 1 {
+			t.Fatalf("Wrong number of files\n  expected: 1\n       got: %d", len(parsed))
+		}
+		if len(issues) != 0 {
+			t.Fatalf("Unexpected issues: %v", issues)
+		}
+	})
+
+	t.Run("build constraints", func(t *testing.T) {
+		path := filepath.Join(dir, "doc.go")
+		key := packageKey{dir: dir, name: "p"}
+		_, _, issues, err := lintPackage(key, []string{path}, "", settings)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(issues) != 0 {
+			t.Fatalf("Unexpected issues: %v", issues)
+		}
+
+		// File with the tag has the first package comment
+		_, _, issues, err = lintPackage(key, []string{path}, "foo", settings)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(issues) != 1 || issues[0].Rule != godot.PackageDocRule {
+			t.Fatalf("Wrong issues: %v", issues)
+		}
+	})
+
+	t.Run("no package comment", func(t *testing.T) {
+		path := filepath.Join(dir, "a.go")
+		key := packageKey{dir: dir, name: "p"}
+		if err := os.Remove(filepath.Join(dir, "doc.go")); err != nil {
+			t.Fatalf("Failed to remove file: %v", err)
+		}
+		_, _, issues, err := lintPackage(key, []string{path}, "", settings)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(issues) != 1 || issues[0].Rule != godot.PackageDocRule {
+			t.Fatalf("Wrong issues: %v", issues)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"sync"
+
+	"github.com/tetafro/godot"
+)
+
+// result is a result of processing a single file.
+type result struct {
+	path     string
+	file     *ast.File
+	fset     *token.FileSet
+	settings godot.Settings
+	issues   []godot.Issue
+	fixed    []byte
+	err      error
+}
+
+// processFiles processes files from the channel using a pool of workers,
+// and calls the handler for every result in the same order as files come
+// from the channel. To keep memory usage flat, only a limited number of
+// files can be processed ahead of the handled ones.
+func processFiles(
+	paths <-chan string,
+	workers int,
+	process func(path string) result,
+	handle func(result),
+) {
+	type job struct {
+		index int
+		path  string
+	}
+	type indexedResult struct {
+		index int
+		result
+	}
+
+	jobs := make(chan job)
+	results := make(chan indexedResult)
+	window := make(chan struct{}, 2*workers) // files in progress
+
+	go func() {
+		defer close(jobs)
+		i := 0
+		for path := range paths {
+			window <- struct{}{}
+			jobs <- job{index: i, path: path}
+			i++
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results <- indexedResult{index: j.index, result: process(j.path)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Handle results in the original order
+	pending := map[int]result{}
+	next := 0
+	for res := range results {
+		pending[res.index] = res.result
+		for {
+			r, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			handle(r)
+			<-window
+			next++
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestProcessFiles(t *testing.T) {
+	const n = 100
+
+	paths := make(chan string)
+	go func() {
+		defer close(paths)
+		for i := 0; i < n; i++ {
+			paths <- strconv.Itoa(i)
+		}
+	}()
+
+	// Make earlier files slower to process
+	process := func(path string) result {
+		i, _ := strconv.Atoi(path)
+		time.Sleep(time.Duration(n-i) * time.Microsecond * 10)
+		return result{path: path}
+	}
+
+	var handled []string
+	handle := func(res result) {
+		handled = append(handled, res.path)
+	}
+
+	processFiles(paths, 8, process, handle)
+
+	if len(handled) != n {
+		t.Fatalf("Wrong number of results\n  expected: %d\n       got: %d", n, len(handled))
+	}
+	for i, path := range handled {
+		if path != strconv.Itoa(i) {
+			t.Fatalf("Wrong order of results\n  expected: %d\n       got: %s", i, path)
+		}
+	}
+}
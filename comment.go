@@ -6,10 +6,45 @@ import "go/token"
 // data attached. The latter is used for creating a full replacement for
 // the line with issues.
 type comment struct {
-	lines []string       // unmodified lines from file
-	text  string         // concatenated `lines` with special parts excluded
-	start token.Position // position of the first symbol in comment
-	decl  bool           // whether comment is a declaration comment
+	lines  []string           // unmodified lines from file
+	text   string             // concatenated `lines` with special parts excluded
+	start  token.Position     // position of the first symbol in comment
+	decl   bool               // whether comment is a declaration comment
+	names  []string           // names of the declared identifier, if any
+	blocks []block            // doc comment blocks of declaration comments, if parsed
+	edits  map[int][]TextEdit // edits of all issues by lines, if collected
+}
+
+// lineOffset returns the offset of the beginning of the comment line,
+// starting at 0. For inline comments, the line starts before the comment,
+// so the column offset is subtracted.
+func (c comment) lineOffset(line int) int {
+	offset := c.start.Offset - (c.start.Column - 1)
+	for i := 0; i < line; i++ {
+		offset += len(c.lines[i]) + 1
+	}
+	return offset
+}
+
+// replace sets the replacement of the issue: the original line with edits
+// of the issue applied. Edits of the previous issues of the comment are
+// applied too, so replacements of different issues can be combined.
+func (c comment) replace(iss *Issue, line int) {
+	edits := iss.Edits
+	if c.edits != nil {
+		c.edits[line] = append(c.edits[line], iss.Edits...)
+		edits = c.edits[line]
+	}
+
+	// Edits use offsets in the file, make them relative to the line
+	start := c.lineOffset(line)
+	shifted := make([]TextEdit, len(edits))
+	for i, e := range edits {
+		e.Pos.Offset -= start
+		e.End.Offset -= start
+		shifted[i] = e
+	}
+	iss.Replacement = string(applyEdits([]byte(c.lines[line]), []Issue{{Edits: shifted}}))
 }
 
 // position is a position inside a comment (might be multiline comment).
@@ -0,0 +1,153 @@
+package godot
+
+import (
+	"go/token"
+	"regexp"
+	"strings"
+)
+
+// Error messages.
+const (
+	deprecatedFormatMessage    = "Deprecation notice should begin with \"Deprecated:\""
+	deprecatedParagraphMessage = "Deprecation notice should be a separate paragraph"
+	deprecatedHintMessage      = "Deprecation notice should describe what to use instead"
+)
+
+const deprecatedPrefix = "Deprecated:"
+
+var (
+	// Deprecation notice at the beginning of the line, e.g. "Deprecated:",
+	// "deprecated:" or "DEPRECATED".
+	deprecatedStart = regexp.MustCompile(`^(?:(?i:deprecated)\s*:|DEPRECATED\b[:.]?)`)
+
+	// Deprecation notice in the middle of the line, e.g. "Deprecated:".
+	deprecatedInline = regexp.MustCompile(`\s(?:(?i:deprecated)\s*:|DEPRECATED\b[:.]?)`)
+)
+
+// checkDeprecated checks that deprecation notices in declaration comments
+// follow the Go convention: a notice is a separate paragraph, that begins
+// with "Deprecated:" and describes what to use instead.
+//
+//nolint:funlen
+func checkDeprecated(c comment) []Issue {
+	if !c.decl {
+		return nil
+	}
+	isBlock := strings.HasPrefix(c.lines[0], "/*")
+
+	var issues []Issue
+	lines := strings.Split(c.text, "\n")
+	for i, line := range lines {
+		if strings.Contains(line, specialReplacer) {
+			// Lowercase notices look like tags, e.g. "//nolint:", so they
+			// are replaced in the text
+			line = rawText(c.lines[i])
+			if !deprecatedStart.MatchString(strings.TrimPrefix(line, " ")) {
+				continue
+			}
+		}
+		trimmed := strings.TrimLeft(line, " \t")
+
+		// Find the notice and its position in the text line
+		start, end := -1, -1
+		inline := false
+		if m := deprecatedStart.FindStringIndex(trimmed); m != nil {
+			start = len(line) - len(trimmed) + m[0]
+			end = len(line) - len(trimmed) + m[1]
+		} else if m := deprecatedInline.FindStringIndex(trimmed); m != nil {
+			start = len(line) - len(trimmed) + m[0] + 1 // skip the space
+			end = len(line) - len(trimmed) + m[1]
+			inline = true
+		}
+		if start < 0 {
+			continue
+		}
+
+		// Position of the notice in the original line
+		original := c.lines[i]
+		idx := strings.Index(original, line)
+		if idx < 0 || line == "" {
+			// This should never happen. Avoid panics, skip this check.
+			continue
+		}
+		offset := c.lineOffset(i)
+		newIssue := func(column int, msg string) Issue {
+			return Issue{
+				Pos: token.Position{
+					Filename: c.start.Filename,
+					Offset:   offset,
+					Line:     i + c.start.Line,
+					Column:   column,
+				},
+				Rule:    DeprecatedRule,
+				Message: msg,
+			}
+		}
+		column := idx + start + 1
+
+		// Check the prefix
+		if line[start:end] != deprecatedPrefix {
+			iss := newIssue(column, deprecatedFormatMessage)
+			iss.Edits = []TextEdit{replaceText(iss.Pos, end-start, deprecatedPrefix)}
+			c.replace(&iss, i)
+			issues = append(issues, iss)
+		}
+
+		// Check that the notice begins a paragraph
+		switch {
+		case inline:
+			// Split the line, e.g. "// Text. Deprecated: ..." becomes
+			// "// Text.\n//\n// Deprecated: ..."
+			before := strings.TrimRight(original[:column-1], " \t")
+			iss := newIssue(len(before)+1, deprecatedParagraphMessage)
+			rep := "\n\n"
+			if !isBlock {
+				indent, ok := lineIndent(original)
+				if !ok {
+					break
+				}
+				rep = "\n" + indent + "//\n" + indent + "// "
+			}
+			iss.Edits = []TextEdit{replaceText(iss.Pos, column-1-len(before), rep)}
+			c.replace(&iss, i)
+			issues = append(issues, iss)
+		case i > 0 && strings.TrimSpace(lines[i-1]) != "":
+			// Insert an empty line before the notice
+			iss := newIssue(1, deprecatedParagraphMessage)
+			rep := "\n"
+			if !isBlock {
+				indent, ok := lineIndent(original)
+				if !ok {
+					break
+				}
+				rep = indent + "//\n"
+			}
+			iss.Edits = []TextEdit{insertText(iss.Pos, rep)}
+			c.replace(&iss, i)
+			issues = append(issues, iss)
+		}
+
+		// Check that the notice has a description: either on the same line,
+		// or on the next lines of the paragraph
+		hasHint := strings.TrimSpace(line[end:]) != ""
+		if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+			hasHint = true
+		}
+		if !hasHint {
+			// There is no fix, so the line is kept as is
+			iss := newIssue(column, deprecatedHintMessage)
+			iss.Replacement = original
+			issues = append(issues, iss)
+		}
+	}
+	return issues
+}
+
+// rawText returns the text of the comment line without the comment
+// symbols and indentation.
+func rawText(line string) string {
+	line = strings.TrimLeft(line, " \t")
+	line = strings.TrimPrefix(line, "//")
+	line = strings.TrimPrefix(line, "/*")
+	return strings.TrimSuffix(line, "*/")
+}
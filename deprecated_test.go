@@ -0,0 +1,132 @@
+package godot
+
+import (
+	"testing"
+)
+
+func TestCheckDeprecated(t *testing.T) {
+	testCases := []struct {
+		name     string
+		comment  string
+		messages []string
+		fixed    string
+	}{
+		{
+			name:    "valid",
+			comment: "// Foo does foo.\n//\n// Deprecated: use Bar instead.\n",
+		},
+		{
+			name:    "valid first paragraph",
+			comment: "// Deprecated: use Bar instead.\n",
+		},
+		{
+			name:    "prose",
+			comment: "// Foo returns deprecated items.\n",
+		},
+		{
+			name:     "uppercase",
+			comment:  "// Foo does foo.\n//\n// DEPRECATED: use Bar instead.\n",
+			messages: []string{deprecatedFormatMessage},
+			fixed:    "// Foo does foo.\n//\n// Deprecated: use Bar instead.\n",
+		},
+		{
+			name:     "lowercase",
+			comment:  "// Foo does foo.\n//\n// deprecated: use Bar instead.\n",
+			messages: []string{deprecatedFormatMessage},
+			fixed:    "// Foo does foo.\n//\n// Deprecated: use Bar instead.\n",
+		},
+		{
+			name:     "uppercase without colon",
+			comment:  "// Foo does foo.\n//\n// DEPRECATED use Bar instead.\n",
+			messages: []string{deprecatedFormatMessage},
+			fixed:    "// Foo does foo.\n//\n// Deprecated: use Bar instead.\n",
+		},
+		{
+			name:     "not a paragraph",
+			comment:  "// Foo does foo.\n// Deprecated: use Bar instead.\n",
+			messages: []string{deprecatedParagraphMessage},
+			fixed:    "// Foo does foo.\n//\n// Deprecated: use Bar instead.\n",
+		},
+		{
+			name:     "inline",
+			comment:  "// Foo does foo. deprecated: use Bar instead.\n",
+			messages: []string{deprecatedParagraphMessage, deprecatedFormatMessage},
+			fixed:    "// Foo does foo.\n//\n// Deprecated: use Bar instead.\n",
+		},
+		{
+			name:     "inline in block comment",
+			comment:  "/* Foo does foo. Deprecated: use Bar instead. */\n",
+			messages: []string{deprecatedParagraphMessage},
+			fixed:    "/* Foo does foo.\n\nDeprecated: use Bar instead. */\n",
+		},
+		{
+			name:     "no hint",
+			comment:  "// Foo does foo.\n//\n// Deprecated:\n",
+			messages: []string{deprecatedHintMessage},
+		},
+		{
+			name:    "hint on the next line",
+			comment: "// Foo does foo.\n//\n// Deprecated:\n// use Bar instead.\n",
+		},
+		{
+			name:    "mixed comment group",
+			comment: "// Foo does foo.\n/*\nText. Deprecated: use Bar.\n*/\n",
+		},
+		{
+			name:    "mixed comment group with separate line",
+			comment: "// Foo does foo.\n/*\nText.\nDeprecated: use Bar.\n*/\n",
+		},
+	}
+
+	settings := Settings{Scope: DeclScope, Deprecated: true}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			testSource(t, settings, tt.comment, "func Foo() {}\n", tt.messages, tt.fixed)
+		})
+	}
+}
+
+func TestCheckDeprecatedReplacement(t *testing.T) {
+	testCases := []struct {
+		name         string
+		comment      string
+		replacements []string
+	}{
+		{
+			name:         "inline",
+			comment:      "// Foo does foo. Deprecated: use Bar instead.\n",
+			replacements: []string{"// Foo does foo.\n//\n// Deprecated: use Bar instead."},
+		},
+		{
+			name:         "not separated",
+			comment:      "// Foo does foo.\n// Deprecated: use Bar instead.\n",
+			replacements: []string{"//\n// Deprecated: use Bar instead."},
+		},
+		{
+			name:         "no hint",
+			comment:      "// Foo does foo.\n//\n// Deprecated:\n",
+			replacements: []string{"// Deprecated:"},
+		},
+	}
+
+	settings := Settings{Scope: DeclScope, Deprecated: true}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package example\n\n" + tt.comment + "func Foo() {}\n"
+			issues, err := RunSource("example.go", []byte(src), settings)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(issues) != len(tt.replacements) {
+				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d",
+					len(tt.replacements), len(issues))
+			}
+			for i, iss := range issues {
+				if iss.Replacement != tt.replacements[i] {
+					t.Fatalf("Wrong replacement\n  expected: %q\n       got: %q",
+						tt.replacements[i], iss.Replacement)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package godot
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+)
+
+// Error messages.
+const (
+	unusedDirectiveMessage  = "Unused godot directive"
+	unknownDirectiveMessage = "Unknown godot directive"
+)
+
+// Directive kinds.
+const (
+	ignoreDirective          = "ignore"            // ignore the next comment
+	ignoreNextBlockDirective = "ignore-next-block" // ignore the next block of code
+	disableDirective         = "disable"           // start ignored region
+	enableDirective          = "enable"            // end ignored region
+	fileIgnoreDirective      = "file-ignore"       // ignore the whole file
+)
+
+const directivePrefix = "//godot:"
+
+// directive is a comment, that suppresses issues, e.g. "//godot:ignore".
+// Issues of the listed rules (or all rules if the list is empty) inside
+// the range of lines are suppressed.
+type directive struct {
+	kind  string
+	rules []string
+	pos   token.Position
+	text  string // the whole line with the directive
+	from  int    // first suppressed line
+	to    int    // last suppressed line
+	used  bool
+}
+
+// getDirectives gets all godot directives from the file.
+func (pf *parsedFile) getDirectives() []*directive {
+	var directives []*directive
+	var disabled []*directive // disable directives without enable
+	for _, cg := range pf.file.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, directivePrefix) {
+				continue
+			}
+			fields := strings.FieldsFunc(
+				strings.TrimPrefix(c.Text, directivePrefix),
+				func(r rune) bool { return r == ' ' || r == '\t' || r == ',' },
+			)
+			if len(fields) == 0 {
+				fields = []string{""}
+			}
+			d := &directive{
+				kind:  fields[0],
+				rules: fields[1:],
+				pos:   pf.fset.Position(c.Slash),
+			}
+			d.text = pf.line(d.pos.Line)
+			line := d.pos.Line
+			// Gofmt moves directives to the end of doc comments, so
+			// the comment group before the directive is covered too
+			groupStart := pf.fset.Position(cg.Pos()).Line
+
+			switch d.kind {
+			case ignoreDirective:
+				// The comment group with the directive and the comment
+				// group on the next line
+				d.from = groupStart
+				d.to = max(pf.fset.Position(cg.End()).Line, line+1)
+				if next := pf.commentAtLine(line + 1); next != nil {
+					d.to = max(d.to, pf.fset.Position(next.End()).Line)
+				}
+			case ignoreNextBlockDirective:
+				d.from = min(groupStart, line+1)
+				d.to = max(pf.nextBlockEnd(line), line+1)
+			case disableDirective:
+				d.from = min(groupStart, line+1)
+				d.to = pf.fset.File(pf.file.Pos()).LineCount()
+				disabled = append(disabled, d)
+			case enableDirective:
+				// Close all matching regions
+				d.used = true
+				for i := len(disabled) - 1; i >= 0; i-- {
+					if len(d.rules) > 0 && !intersects(d.rules, disabled[i].rules) {
+						continue
+					}
+					disabled[i].to = line
+					disabled = slices.Delete(disabled, i, i+1)
+				}
+			case fileIgnoreDirective:
+				d.from = 1
+				d.to = pf.fset.File(pf.file.Pos()).LineCount()
+			}
+			directives = append(directives, d)
+		}
+	}
+	return directives
+}
+
+// commentAtLine returns the comment group, that contains the line.
+func (pf *parsedFile) commentAtLine(line int) *ast.CommentGroup {
+	for _, cg := range pf.file.Comments {
+		if pf.fset.Position(cg.Pos()).Line <= line && line <= pf.fset.Position(cg.End()).Line {
+			return cg
+		}
+	}
+	return nil
+}
+
+// nextBlockEnd returns the last line of the first block of code (declaration,
+// statement, spec or field), that starts after the line.
+func (pf *parsedFile) nextBlockEnd(line int) int {
+	var start, end int
+	ast.Inspect(pf.file, func(n ast.Node) bool {
+		switch n.(type) {
+		case ast.Decl, ast.Stmt, ast.Spec, *ast.Field:
+		default:
+			return true
+		}
+		first := pf.fset.Position(n.Pos()).Line
+		last := pf.fset.Position(n.End()).Line
+		if first <= line {
+			return true
+		}
+		if start == 0 || first < start || (first == start && last > end) {
+			start, end = first, last
+		}
+		return false
+	})
+	return end
+}
+
+// applyDirectives removes suppressed issues, and adds issues for unknown
+// and unused directives.
+func applyDirectives(issues []Issue, directives []*directive, settings Settings) []Issue {
+	if len(directives) == 0 {
+		return issues
+	}
+
+	filtered := filterDirectives(issues, directives)
+	for _, d := range directives {
+		switch {
+		case !d.known():
+			filtered = append(filtered, directiveIssue(d, unknownDirectiveMessage))
+		case !d.used && d.hasEnabledRules(settings):
+			filtered = append(filtered, directiveIssue(d, unusedDirectiveMessage))
+		}
+	}
+
+	return filtered
+}
+
+// filterDirectives removes suppressed issues, and marks directives, that
+// suppressed anything, as used.
+func filterDirectives(issues []Issue, directives []*directive) []Issue {
+	if len(directives) == 0 {
+		return issues
+	}
+
+	filtered := make([]Issue, 0, len(issues))
+	for _, iss := range issues {
+		suppressed := false
+		for _, d := range directives {
+			if d.suppresses(iss) {
+				d.used = true
+				suppressed = true
+			}
+		}
+		if !suppressed {
+			filtered = append(filtered, iss)
+		}
+	}
+	return filtered
+}
+
+// suppresses checks if the directive suppresses the issue.
+func (d *directive) suppresses(iss Issue) bool {
+	if !d.known() || d.kind == enableDirective {
+		return false
+	}
+	if iss.Pos.Line < d.from || iss.Pos.Line > d.to {
+		return false
+	}
+	return len(d.rules) == 0 || slices.Contains(d.rules, iss.Rule)
+}
+
+// known checks if the directive kind is known.
+func (d *directive) known() bool {
+	switch d.kind {
+	case ignoreDirective, ignoreNextBlockDirective, disableDirective,
+		enableDirective, fileIgnoreDirective:
+		return true
+	}
+	return false
+}
+
+// hasEnabledRules checks if any of the directive rules is enabled
+// in settings. Directives for disabled rules are never used, so they
+// should not be reported. Package level rules are checked separately
+// from the file, so they are not counted too, and directives for all
+// rules are not reported if any package level rule is enabled.
+func (d *directive) hasEnabledRules(settings Settings) bool {
+	if len(d.rules) == 0 {
+		return !settings.PackageDoc && !settings.DocLinks
+	}
+	for _, rule := range d.rules {
+		if settings.enabled(rule) && rule != PackageDocRule && rule != DocLinkRule {
+			return true
+		}
+	}
+	return false
+}
+
+func directiveIssue(d *directive, msg string) Issue {
+	pos := d.pos
+	pos.Offset -= pos.Column - 1 // offset of the line start
+	return Issue{
+		Pos:         pos,
+		Rule:        DirectiveRule,
+		Message:     msg,
+		Replacement: d.text,
+	}
+}
+
+// intersects checks if two lists have common elements.
+func intersects(a, b []string) bool {
+	for _, s := range a {
+		if slices.Contains(b, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package godot
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDirectives(t *testing.T) {
+	testFile := filepath.Join("testdata", "directive", "main.go")
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, testFile, nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse input file: %v", err)
+	}
+
+	// Get expected rules for every line from the tags
+	tags := map[string]string{
+		"[PERIOD]":  PeriodRule,
+		"[CAPITAL]": CapitalRule,
+	}
+	expected := map[int]string{}
+	for _, cg := range file.Comments {
+		// Directives can't contain tags, so standalone ones (except
+		// closing ones) are expected to be reported as unused or unknown
+		text := cg.List[0].Text
+		if len(cg.List) == 1 && strings.HasPrefix(text, directivePrefix) &&
+			!strings.HasPrefix(text, directivePrefix+enableDirective) {
+			expected[fset.Position(cg.Pos()).Line] = DirectiveRule
+		}
+		for _, c := range cg.List {
+			for tag, rule := range tags {
+				if strings.Contains(c.Text, tag) {
+					expected[fset.Position(c.Pos()).Line] = rule
+				}
+			}
+		}
+	}
+
+	issues, err := Run(file, fset, Settings{
+		Scope:   AllScope,
+		Period:  true,
+		Capital: true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := map[int]string{}
+	for _, iss := range issues {
+		got[iss.Pos.Line] = iss.Rule
+	}
+	for line, rule := range expected {
+		if got[line] != rule {
+			t.Fatalf("Wrong issue on line %d\n  expected: %s\n       got: %s",
+				line, rule, got[line])
+		}
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d\n%v",
+			len(expected), len(issues), issues)
+	}
+}
+
+func TestFileIgnoreDirective(t *testing.T) {
+	testCases := []struct {
+		name   string
+		src    string
+		issues int
+	}{
+		{
+			name:   "ignore all rules",
+			src:    "//godot:file-ignore\npackage main\n\n// comment\nfunc main() {}\n",
+			issues: 0,
+		},
+		{
+			name:   "ignore one rule",
+			src:    "//godot:file-ignore capital\npackage main\n\n// Comment. another\nfunc main() {}\n",
+			issues: 1,
+		},
+		{
+			name:   "unused",
+			src:    "//godot:file-ignore\npackage main\n\n// Comment.\nfunc main() {}\n",
+			issues: 1,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := RunSource("main.go", []byte(tt.src), Settings{
+				Scope:   DeclScope,
+				Period:  true,
+				Capital: true,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(issues) != tt.issues {
+				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d",
+					tt.issues, len(issues))
+			}
+		})
+	}
+}
+
+func TestDirectiveLayout(t *testing.T) {
+	testCases := []struct {
+		name   string
+		src    string
+		issues int
+	}{
+		{
+			name:   "ignore before comment",
+			src:    "//godot:ignore\n// Foo x\nfunc Foo() {}\n",
+			issues: 0,
+		},
+		{
+			name:   "ignore at the end of comment",
+			src:    "// Foo x\n//\n//godot:ignore\nfunc Foo() {}\n",
+			issues: 0,
+		},
+		{
+			name:   "ignore next block before comment",
+			src:    "//godot:ignore-next-block\n// Foo x\nfunc Foo() {\n\t// inner x\n}\n",
+			issues: 0,
+		},
+		{
+			name:   "ignore next block at the end of comment",
+			src:    "// Foo x\n//\n//godot:ignore-next-block\nfunc Foo() {\n\t// inner x\n}\n",
+			issues: 0,
+		},
+		{
+			name:   "disable before comment",
+			src:    "//godot:disable\n// Foo x\nfunc Foo() {}\n",
+			issues: 0,
+		},
+		{
+			name:   "disable at the end of comment",
+			src:    "// Bar x\nfunc Bar() {}\n\n// Foo x\n//\n//godot:disable\nfunc Foo() {}\n",
+			issues: 1,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			src := "package main\n\n" + tt.src
+			issues, err := RunSource("main.go", []byte(src), Settings{
+				Scope:  AllScope,
+				Period: true,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(issues) != tt.issues {
+				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d\n%v",
+					tt.issues, len(issues), issues)
+			}
+		})
+	}
+}
+
+func TestPackageLevelDirective(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		settings Settings
+		issues   int
+	}{
+		{
+			name:     "ignore package comment",
+			src:      "//godot:ignore\npackage q\n",
+			settings: Settings{Scope: DeclScope, Period: true, PackageDoc: true},
+			issues:   0,
+		},
+		{
+			name:     "ignore package comment rule",
+			src:      "//godot:ignore package-doc\npackage q\n",
+			settings: Settings{Scope: DeclScope, Period: true, PackageDoc: true},
+			issues:   0,
+		},
+		{
+			name:     "unused",
+			src:      "//godot:ignore\npackage q\n",
+			settings: Settings{Scope: DeclScope, Period: true},
+			issues:   1,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, "q.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatalf("Failed to parse input file: %v", err)
+			}
+			issues, err := RunSource("q.go", []byte(tt.src), tt.settings)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			pkgIssues, err := runPackage([]*ast.File{file}, fset, [][]byte{[]byte(tt.src)}, nil, tt.settings)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			issues = append(issues, pkgIssues...)
+			if len(issues) != tt.issues {
+				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d\n%v",
+					tt.issues, len(issues), issues)
+			}
+		})
+	}
+}
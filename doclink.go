@@ -0,0 +1,307 @@
+package godot
+
+import (
+	"fmt"
+	"go/ast"
+	doccomment "go/doc/comment"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Error messages.
+const (
+	unknownDocLinkMessage = "Doc link [%s] refers to an unknown identifier"
+)
+
+// Major version suffix of an import path, e.g. "/v2" or ".v3".
+var majorVersion = regexp.MustCompile(`[/.]v[0-9]+$`)
+
+// symbols contains declarations of the package, that can be referred
+// by doc links.
+type symbols struct {
+	decls   map[string]bool            // top level declarations
+	members map[string]map[string]bool // methods and fields of types
+	embeds  map[string][]string        // embedded types of the package by types
+}
+
+// getSymbols collects declarations of the package from its files. Test
+// files are not a part of the documentation, so they are skipped.
+func getSymbols(files []*ast.File, fset *token.FileSet) symbols {
+	syms := symbols{
+		decls:   map[string]bool{},
+		members: map[string]map[string]bool{},
+		embeds:  map[string][]string{},
+	}
+	addMember := func(typ, name string) {
+		if syms.members[typ] == nil {
+			syms.members[typ] = map[string]bool{}
+		}
+		syms.members[typ][name] = true
+	}
+
+	for _, file := range files {
+		if file == nil || strings.HasSuffix(getFilename(fset, file), "_test.go") {
+			continue
+		}
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					syms.decls[d.Name.Name] = true
+					continue
+				}
+				if id, ok := receiverType(d.Recv.List[0].Type).(*ast.Ident); ok {
+					addMember(id.Name, d.Name.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					for _, name := range specNames(spec) {
+						syms.decls[name] = true
+					}
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					var list *ast.FieldList
+					switch t := ts.Type.(type) {
+					case *ast.StructType:
+						list = t.Fields
+					case *ast.InterfaceType:
+						list = t.Methods
+					}
+					if list == nil {
+						continue
+					}
+					for _, field := range list.List {
+						for _, name := range field.Names {
+							addMember(ts.Name.Name, name.Name)
+						}
+						// Embedded fields
+						if len(field.Names) == 0 {
+							if id, ok := embeddedName(field.Type); ok {
+								addMember(ts.Name.Name, id)
+							}
+							if id, ok := receiverType(field.Type).(*ast.Ident); ok {
+								syms.embeds[ts.Name.Name] = append(syms.embeds[ts.Name.Name], id.Name)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return syms
+}
+
+// hasMember checks if the type has the method or the field. Members
+// of embedded types of the package are promoted, so they are checked too.
+func (s symbols) hasMember(typ, name string) bool {
+	seen := map[string]bool{}
+	queue := []string{typ}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		if s.members[t][name] {
+			return true
+		}
+		queue = append(queue, s.embeds[t]...)
+	}
+	return false
+}
+
+// embeddedName returns the name of the embedded field.
+func embeddedName(expr ast.Expr) (string, bool) {
+	switch e := receiverType(expr).(type) {
+	case *ast.Ident:
+		return e.Name, true
+	case *ast.SelectorExpr:
+		return e.Sel.Name, true
+	}
+	return "", false
+}
+
+// checkDocLinks checks that doc links in comments of the file, e.g. [Name],
+// [pkg.Name] or [*T.Method], refer to existing identifiers. Links to other
+// packages are checked only if type information is available.
+func checkDocLinks(pf *parsedFile, syms symbols, pkg *types.Package, exclude []*regexp.Regexp, settings Settings) []Issue {
+	imports := fileImports(pf.file, pkg)
+	parser := doccomment.Parser{
+		LookupPackage: func(name string) (string, bool) {
+			importPath, ok := imports[name]
+			return importPath, ok
+		},
+		// Treat all symbols as links to validate them later
+		LookupSym: func(_, _ string) bool { return true },
+	}
+
+	var issues []Issue
+	for _, c := range pf.getComments(settings.Scope, exclude, settings.Fields) {
+		lines := make([]string, len(c.lines))
+		for i, line := range c.lines {
+			if i == 0 && c.start.Column-1 < len(line) {
+				// Skip the code before inline comments
+				line = strings.Repeat(" ", c.start.Column-1) + line[c.start.Column-1:]
+			}
+			if matchAny(rawText(line), exclude) {
+				continue
+			}
+			lines[i] = line
+		}
+		text := make([]string, len(lines))
+		for i, line := range lines {
+			text[i] = strings.TrimPrefix(rawText(line), " ")
+		}
+
+		// Find every link in the comment lines, links can't be split
+		// between lines
+		var line, col int
+		for _, link := range docLinks(parser.Parse(strings.Join(text, "\n"))) {
+			s := "[" + plainText(link.Text) + "]"
+			for line < len(lines) {
+				if idx := strings.Index(lines[line][col:], s); idx >= 0 {
+					col += idx + len(s)
+					break
+				}
+				line++
+				col = 0
+			}
+			if line == len(lines) {
+				break // this should never happen
+			}
+			if validDocLink(link, syms, pkg) {
+				continue
+			}
+			issues = append(issues, Issue{
+				Pos: token.Position{
+					Filename: c.start.Filename,
+					Offset:   c.lineOffset(line),
+					Line:     c.start.Line + line,
+					Column:   col - len(s) + 1,
+				},
+				Rule:        DocLinkRule,
+				Message:     fmt.Sprintf(unknownDocLinkMessage, plainText(link.Text)),
+				Replacement: c.lines[line],
+			})
+		}
+	}
+	return issues
+}
+
+// fileImports returns import paths of the file by package names. Package
+// names are taken from the type information, if it's available, otherwise
+// they are guessed from import paths.
+func fileImports(file *ast.File, pkg *types.Package) map[string]string {
+	names := map[string]string{}
+	if pkg != nil {
+		for _, imp := range pkg.Imports() {
+			names[imp.Path()] = imp.Name()
+		}
+	}
+
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name, ok := names[importPath]
+		if !ok {
+			name = path.Base(majorVersion.ReplaceAllString(importPath, ""))
+		}
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+// docLinks returns all doc links of the doc comment.
+func docLinks(doc *doccomment.Doc) []*doccomment.DocLink {
+	var links []*doccomment.DocLink
+	addLinks := func(text []doccomment.Text) {
+		for _, t := range text {
+			if link, ok := t.(*doccomment.DocLink); ok {
+				links = append(links, link)
+			}
+		}
+	}
+	for _, b := range doc.Content {
+		switch b := b.(type) {
+		case *doccomment.Paragraph:
+			addLinks(b.Text)
+		case *doccomment.Heading:
+			addLinks(b.Text)
+		case *doccomment.List:
+			for _, item := range b.Items {
+				for _, p := range item.Content {
+					if p, ok := p.(*doccomment.Paragraph); ok {
+						addLinks(p.Text)
+					}
+				}
+			}
+		}
+	}
+	return links
+}
+
+// plainText returns the text without formatting.
+func plainText(text []doccomment.Text) string {
+	var s string
+	for _, t := range text {
+		switch t := t.(type) {
+		case doccomment.Plain:
+			s += string(t)
+		case doccomment.Italic:
+			s += string(t)
+		}
+	}
+	return s
+}
+
+// validDocLink checks if the doc link refers to an existing identifier.
+// Links to unknown packages are considered valid.
+func validDocLink(link *doccomment.DocLink, syms symbols, pkg *types.Package) bool {
+	if link.ImportPath == "" || (pkg != nil && link.ImportPath == pkg.Path()) {
+		if link.Recv == "" {
+			return syms.decls[link.Name] || types.Universe.Lookup(link.Name) != nil
+		}
+		return syms.hasMember(link.Recv, link.Name)
+	}
+
+	if pkg == nil {
+		return true
+	}
+	var imported *types.Package
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == link.ImportPath {
+			imported = imp
+			break
+		}
+	}
+	if imported == nil || link.Name == "" {
+		return true
+	}
+
+	if link.Recv == "" {
+		obj := imported.Scope().Lookup(link.Name)
+		return obj != nil && obj.Exported()
+	}
+	tn, ok := imported.Scope().Lookup(link.Recv).(*types.TypeName)
+	if !ok {
+		return false
+	}
+	member, _, _ := types.LookupFieldOrMethod(tn.Type(), true, imported, link.Name)
+	return member != nil
+}
@@ -0,0 +1,157 @@
+package godot
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestCheckDocLinks(t *testing.T) {
+	testCases := []struct {
+		name   string
+		files  map[string]string
+		typed  bool
+		issues []string // "file:line:column: message"
+	}{
+		{
+			name: "valid",
+			files: map[string]string{
+				"a.go": "package example\n\n" +
+					"// Foo returns [Bar], see [Bar.Baz], [*Bar.Qux] and [Gen].\n" +
+					"func Foo() Bar { return Bar{} }\n\n" +
+					"// Bar is a bar, it can be compared to [error] and [strings.Builder].\n" +
+					"type Bar struct{ Qux int }\n\n" +
+					"// Baz does baz.\n" +
+					"func (Bar) Baz() {}\n",
+				"gen.go": "// Code generated by tool. DO NOT EDIT.\n\npackage example\n\ntype Gen int\n",
+			},
+		},
+		{
+			name: "unknown identifiers",
+			files: map[string]string{
+				"a.go": "package example\n\n" +
+					"// Foo returns [Baz].\n" +
+					"func Foo() Bar { return Bar{} }\n\n" +
+					"// Bar is a bar, see [Bar.Qux] and\n" +
+					"// [Foo].\n" +
+					"type Bar struct{}\n",
+			},
+			issues: []string{
+				"a.go:3:16: Doc link [Baz] refers to an unknown identifier",
+				"a.go:6:22: Doc link [Bar.Qux] refers to an unknown identifier",
+			},
+		},
+		{
+			name: "promoted members",
+			files: map[string]string{
+				"a.go": "package example\n\n" +
+					"type base struct{ inner }\n\n" +
+					"func (base) Run() {}\n\n" +
+					"type inner struct{ Count int }\n\n" +
+					"// T calls [T.Run] and [T.Count], but not [T.Stop].\n" +
+					"type T struct{ *base }\n",
+			},
+			issues: []string{"a.go:9:43: Doc link [T.Stop] refers to an unknown identifier"},
+		},
+		{
+			name: "declarations of test files",
+			files: map[string]string{
+				"a.go":      "package example\n\n// Foo returns [Bar].\nfunc Foo() {}\n",
+				"a_test.go": "package example\n\ntype Bar int\n",
+			},
+			issues: []string{"a.go:3:16: Doc link [Bar] refers to an unknown identifier"},
+		},
+		{
+			name: "code blocks and link definitions",
+			files: map[string]string{
+				"a.go": "package example\n\n" +
+					"// Foo uses [Go], for example:\n" +
+					"//\n" +
+					"//\t[Bar]\n" +
+					"//\n" +
+					"// [Go]: https://go.dev\n" +
+					"func Foo() {}\n",
+			},
+		},
+		{
+			name: "imported package without type information",
+			files: map[string]string{
+				"a.go": "package example\n\nimport \"strings\"\n\n" +
+					"// Foo returns [strings.Bilder].\n" +
+					"func Foo() strings.Builder { return strings.Builder{} }\n",
+			},
+		},
+		{
+			name: "imported package",
+			files: map[string]string{
+				"a.go": "package example\n\nimport \"strings\"\n\n" +
+					"// Foo returns [strings.Builder], see [strings.Bilder] and\n" +
+					"// [*strings.Builder.Len] or [strings.Builder.Size].\n" +
+					"func Foo() strings.Builder { return strings.Builder{} }\n",
+			},
+			typed: true,
+			issues: []string{
+				"a.go:5:39: Doc link [strings.Bilder] refers to an unknown identifier",
+				"a.go:6:30: Doc link [strings.Builder.Size] refers to an unknown identifier",
+			},
+		},
+		{
+			name: "directive",
+			files: map[string]string{
+				"a.go": "package example\n\n//godot:ignore-next-block doc-links\n// Foo returns [Baz].\nfunc Foo() {}\n",
+			},
+		},
+	}
+
+	settings := Settings{Scope: DeclScope, DocLinks: true}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			var files []*ast.File
+			var srcs [][]byte
+			for name, src := range tt.files {
+				f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+				if err != nil {
+					t.Fatalf("Failed to parse input file: %v", err)
+				}
+				files = append(files, f)
+				srcs = append(srcs, []byte(src))
+			}
+
+			var pkg *types.Package
+			if tt.typed {
+				conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+				var err error
+				pkg, err = conf.Check("example", fset, files, nil)
+				if err != nil {
+					t.Fatalf("Failed to check types: %v", err)
+				}
+			}
+
+			issues, err := runPackage(files, fset, srcs, pkg, settings)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(issues) != len(tt.issues) {
+				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d (%v)",
+					len(tt.issues), len(issues), issues)
+			}
+			for i, iss := range issues {
+				got := filepath.Base(iss.Pos.Filename) + ":" +
+					strconv.Itoa(iss.Pos.Line) + ":" +
+					strconv.Itoa(iss.Pos.Column) + ": " + iss.Message
+				if got != tt.issues[i] {
+					t.Fatalf("Wrong issue\n  expected: %s\n       got: %s", tt.issues[i], got)
+				}
+				if iss.Rule != DocLinkRule {
+					t.Fatalf("Wrong rule\n  expected: %s\n       got: %s", DocLinkRule, iss.Rule)
+				}
+			}
+		})
+	}
+}
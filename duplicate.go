@@ -0,0 +1,168 @@
+package godot
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Error messages.
+const (
+	duplicateWordMessage = "Duplicate word %q"
+)
+
+// word is a word in a comment text line.
+type word struct {
+	text  string
+	line  int // index of the line, starts at 0
+	start int // byte index in the text line
+	end   int
+	first bool // whether the word begins a sentence
+}
+
+// checkDuplicateWords checks that the comment doesn't contain the same word
+// twice in a row, e.g. "the the". Words can be separated by line breaks,
+// but not by punctuation or empty lines. Special lines, e.g. code examples,
+// are skipped.
+//
+//nolint:funlen
+func checkDuplicateWords(c comment) []Issue {
+	text := strings.Split(c.text, "\n")
+	if len(text) != len(c.lines) {
+		// This should never happen. Avoid panics, skip this check.
+		return nil
+	}
+
+	// Find duplicates and their positions in the original lines
+	type duplicate struct {
+		prev, cur word
+		idx       int // index of the text line in the original line
+	}
+	var dups []duplicate
+	var prev *word
+	sentence := true // the next word begins a sentence
+	for i, line := range text {
+		if strings.Contains(line, specialReplacer) || strings.TrimSpace(line) == "" {
+			prev = nil
+			sentence = true
+			continue
+		}
+		idx := strings.Index(c.lines[i], line)
+		if idx < 0 {
+			prev = nil
+			continue
+		}
+		end := 0
+		for _, w := range lineWords(line, i) {
+			// Words must be separated by spaces or line breaks only
+			between := strings.TrimSpace(line[end:w.start])
+			separated := between == ""
+			if prev != nil && prev.line != i {
+				separated = separated && strings.TrimSpace(text[prev.line][prev.end:]) == ""
+			}
+			if between != "" {
+				sentence = endsSentence(between)
+			}
+			w.first = sentence
+			sentence = false
+			if prev != nil && separated && sameWords(*prev, w) {
+				dups = append(dups, duplicate{prev: *prev, cur: w, idx: idx})
+			}
+			prev = &w
+			end = w.end
+		}
+		if rest := strings.TrimSpace(line[end:]); rest != "" {
+			sentence = endsSentence(rest)
+		}
+	}
+
+	issues := make([]Issue, 0, len(dups))
+	for _, d := range dups {
+		iss := Issue{
+			Pos: token.Position{
+				Filename: c.start.Filename,
+				Offset:   c.lineOffset(d.cur.line),
+				Line:     d.cur.line + c.start.Line,
+				Column:   d.idx + d.cur.start + 1,
+			},
+			Rule:    DuplicateWordsRule,
+			Message: fmt.Sprintf(duplicateWordMessage, d.cur.text),
+		}
+
+		// Remove the duplicate with spaces before it. If the duplicate
+		// is the first word of the line, remove spaces after it.
+		original := c.lines[d.cur.line]
+		from, to := d.idx+d.cur.start, d.idx+d.cur.end
+		if d.prev.line == d.cur.line {
+			from = d.idx + d.prev.end
+		} else {
+			to = len(original) - len(strings.TrimLeft(original[to:], " \t"))
+			if to == len(original) {
+				// Nothing left in the line
+				from = len(strings.TrimRight(original[:from], " \t"))
+			}
+		}
+		pos := iss.Pos
+		pos.Column = from + 1
+		iss.Edits = []TextEdit{replaceText(pos, to-from, "")}
+		c.replace(&iss, d.cur.line)
+
+		issues = append(issues, iss)
+	}
+	return issues
+}
+
+// lineWords splits the comment text line into words. Words consist of
+// letters, digits, underscores and apostrophes, and contain at least
+// one letter.
+func lineWords(line string, i int) []word {
+	isWordChar := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '\''
+	}
+
+	var words []word
+	start := -1
+	for j := 0; j <= len(line); {
+		r, size := utf8.DecodeRuneInString(line[j:])
+		if j < len(line) && isWordChar(r) {
+			if start < 0 {
+				start = j
+			}
+			j += size
+			continue
+		}
+		if start >= 0 && strings.ContainsFunc(line[start:j], unicode.IsLetter) {
+			words = append(words, word{text: line[start:j], line: i, start: start, end: j})
+		}
+		start = -1
+		if j == len(line) {
+			break
+		}
+		j += size
+	}
+	return words
+}
+
+// sameWords checks if the second word repeats the first one. The first
+// letter may differ in case only if the first word begins a sentence,
+// e.g. "The the". Otherwise words in different case are usually different
+// identifiers, e.g. a type and a variable in "return PC pc".
+func sameWords(a, b word) bool {
+	if a.text == b.text {
+		return true
+	}
+	if !a.first {
+		return false
+	}
+	r1, size1 := utf8.DecodeRuneInString(a.text)
+	r2, size2 := utf8.DecodeRuneInString(b.text)
+	return unicode.ToLower(r1) == unicode.ToLower(r2) && a.text[size1:] == b.text[size2:]
+}
+
+// endsSentence checks if the text ends with a sentence ending.
+func endsSentence(text string) bool {
+	r, _ := utf8.DecodeLastRuneInString(text)
+	return strings.ContainsRune(".!?:", r)
+}
@@ -0,0 +1,94 @@
+package godot
+
+import (
+	"testing"
+)
+
+func TestCheckDuplicateWords(t *testing.T) {
+	testCases := []struct {
+		name     string
+		comment  string
+		messages []string
+		fixed    string
+	}{
+		{
+			name:    "valid",
+			comment: "// Foo is the foo.\n",
+		},
+		{
+			name:     "same line",
+			comment:  "// Foo is is the foo.\n",
+			messages: []string{`Duplicate word "is"`},
+			fixed:    "// Foo is the foo.\n",
+		},
+		{
+			name:     "different case",
+			comment:  "// Foo is foo. The the foo is a foo.\n",
+			messages: []string{`Duplicate word "the"`},
+			fixed:    "// Foo is foo. The foo is a foo.\n",
+		},
+		{
+			name:     "line break",
+			comment:  "// Foo is the\n// the foo.\n",
+			messages: []string{`Duplicate word "the"`},
+			fixed:    "// Foo is the\n// foo.\n",
+		},
+		{
+			name:     "word in a separate line",
+			comment:  "// Foo is the\n// the\n// foo.\n",
+			messages: []string{`Duplicate word "the"`},
+			fixed:    "// Foo is the\n//\n// foo.\n",
+		},
+		{
+			name:     "multiple duplicates",
+			comment:  "// Foo is is is the the foo.\n",
+			messages: []string{`Duplicate word "is"`, `Duplicate word "is"`, `Duplicate word "the"`},
+			fixed:    "// Foo is the foo.\n",
+		},
+		{
+			name:    "punctuation",
+			comment: "// Foo is foo. Foo is bar.\n",
+		},
+		{
+			name:    "punctuation at line break",
+			comment: "// Foo is foo,\n// foo is bar.\n",
+		},
+		{
+			name:    "paragraphs",
+			comment: "// Foo is a foo\n//\n// foo is bar.\n",
+		},
+		{
+			name:    "code block",
+			comment: "// Foo is foo:\n//\n//\tfoo foo\n",
+		},
+		{
+			name:     "sentence start",
+			comment:  "// Foo is foo.\n// The the foo is a foo.\n",
+			messages: []string{`Duplicate word "the"`},
+			fixed:    "// Foo is foo.\n// The foo is a foo.\n",
+		},
+		{
+			name:    "type and variable",
+			comment: "// Foo returns PC pc.\n",
+		},
+		{
+			name:    "type and variable with first letter only",
+			comment: "// Foo is called for every P p when p is idle.\n",
+		},
+		{
+			name:    "different case in the middle of sentence",
+			comment: "// Foo returns Bar bar.\n",
+		},
+		{
+			name:    "numbers",
+			comment: "// Foo is 1 1 foo.\n",
+		},
+	}
+
+	settings := Settings{Scope: DeclScope, DuplicateWords: true}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			testSource(t, settings, tt.comment, "func Foo() {}\n", tt.messages, tt.fixed)
+		})
+	}
+}
@@ -24,8 +24,10 @@ type parsedFile struct {
 	lines []string
 }
 
-func newParsedFile(file *ast.File, fset *token.FileSet) (*parsedFile, error) {
-	if file == nil || fset == nil || len(file.Comments) == 0 {
+// newParsedFile creates a parsed file from AST and the original source code.
+// If the source is nil, it is read from the file.
+func newParsedFile(file *ast.File, fset *token.FileSet, src []byte) (*parsedFile, error) {
+	if file == nil || fset == nil {
 		return nil, errEmptyInput
 	}
 
@@ -34,29 +36,41 @@ func newParsedFile(file *ast.File, fset *token.FileSet) (*parsedFile, error) {
 		file: file,
 	}
 
-	// Read original file. This is necessary for making a replacements for
-	// inline comments. I couldn't find a better way to get original line
-	// with code and comment without reading the file. Function `Format`
-	// from "go/format" won't help here if the original file is not gofmt-ed.
-
 	filename := getFilename(fset, file)
 
 	if !strings.HasSuffix(filename, ".go") {
 		return nil, errEmptyInput
 	}
 
-	var err error
-
-	pf.lines, err = readFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("read file: %w", err)
+	// Read original file if the source is not provided. This is necessary
+	// for making a replacements for inline comments. I couldn't find a better
+	// way to get original line with code and comment without reading the file.
+	// Function `Format` from "go/format" won't help here if the original file
+	// is not gofmt-ed.
+	if src == nil {
+		var err error
+		src, err = os.ReadFile(filepath.Clean(filename))
+		if err != nil {
+			return nil, fmt.Errorf("read file: %w", err)
+		}
 	}
+	pf.lines = strings.Split(string(src), "\n")
 
 	return &pf, nil
 }
 
-// getComments extracts comments from a file.
-func (pf *parsedFile) getComments(scope Scope, exclude []*regexp.Regexp) []comment {
+// line returns the original line of the file, starting at 1. Lines out
+// of range, e.g. because of the `//line` directive, are empty.
+func (pf *parsedFile) line(n int) string {
+	if n < 1 || n > len(pf.lines) {
+		return ""
+	}
+	return pf.lines[n-1]
+}
+
+// getComments extracts comments from a file. If `fields` is set, doc
+// comments of struct fields and interface methods are added to the scope.
+func (pf *parsedFile) getComments(scope Scope, exclude []*regexp.Regexp, fields bool) []comment {
 	var comments []comment
 	decl := pf.getDeclarationComments(exclude)
 	switch scope {
@@ -77,11 +91,28 @@ func (pf *parsedFile) getComments(scope Scope, exclude []*regexp.Regexp) []comme
 		// Top level declaration comments and comments from the inside
 		// of top level blocks
 		comments = append(pf.getBlockComments(exclude), decl...)
+	case ExportedScope:
+		// Comments of exported declarations and package comment
+		comments = pf.getExportedComments(exclude)
+	}
+
+	// Add field comments, that are not in the scope yet
+	if fields {
+		fieldComments := pf.getFieldComments(exclude)
+		for _, fc := range fieldComments {
+			if !containsComment(comments, fc) {
+				comments = append(comments, fc)
+			}
+		}
+		decl = append(decl, fieldComments...)
 	}
 
 	// Set `decl` flag
 	setDecl(comments, decl)
 
+	// Set names of declared identifiers
+	setNames(comments, pf.getDeclarationNames())
+
 	return comments
 }
 
@@ -183,6 +214,137 @@ func (pf *parsedFile) getDeclarationComments(exclude []*regexp.Regexp) []comment
 	return comments
 }
 
+// getExportedComments gets package comment and doc comments of exported
+// top level declarations, the same as rendered by `go doc`. Methods are
+// exported if both the method and its receiver type are exported.
+func (pf *parsedFile) getExportedComments(exclude []*regexp.Regexp) []comment {
+	groups := []*ast.CommentGroup{pf.file.Doc}
+	for _, decl := range pf.file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if isExportedFunc(d) {
+				groups = append(groups, d.Doc)
+			}
+		case *ast.GenDecl:
+			exported := false
+			for _, spec := range d.Specs {
+				if !isExportedSpec(spec) {
+					continue
+				}
+				exported = true
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					groups = append(groups, s.Doc)
+				case *ast.ValueSpec:
+					groups = append(groups, s.Doc)
+				}
+			}
+			if exported {
+				groups = append(groups, d.Doc)
+			}
+		}
+	}
+
+	var comments []comment
+	for _, cg := range groups {
+		if cg == nil || len(cg.List) == 0 {
+			continue
+		}
+		firstLine := pf.fset.Position(cg.Pos()).Line
+		lastLine := pf.fset.Position(cg.End()).Line
+		if firstLine < 1 || lastLine < firstLine || lastLine > len(pf.lines) {
+			continue // broken consistency, probably by the `//line` directive
+		}
+		comments = append(comments, comment{
+			lines: pf.lines[firstLine-1 : lastLine],
+			text:  getText(cg, exclude),
+			start: pf.fset.Position(cg.List[0].Slash),
+		})
+	}
+	return comments
+}
+
+// getFieldComments gets doc comments of exported fields of top level
+// exported struct types, and doc comments of exported methods of top level
+// exported interface types.
+func (pf *parsedFile) getFieldComments(exclude []*regexp.Regexp) []comment {
+	var comments []comment
+	for _, decl := range pf.file.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range d.Specs {
+			s, ok := spec.(*ast.TypeSpec)
+			if !ok || !s.Name.IsExported() {
+				continue
+			}
+			var list *ast.FieldList
+			switch t := s.Type.(type) {
+			case *ast.StructType:
+				list = t.Fields
+			case *ast.InterfaceType:
+				list = t.Methods
+			}
+			if list == nil {
+				continue
+			}
+			for _, f := range list.List {
+				cg := f.Doc
+				if cg == nil || len(cg.List) == 0 || !isExportedField(f) {
+					continue
+				}
+				firstLine := pf.fset.Position(cg.Pos()).Line
+				lastLine := pf.fset.Position(cg.End()).Line
+				if firstLine < 1 || lastLine < firstLine || lastLine > len(pf.lines) {
+					continue // broken consistency, probably by the `//line` directive
+				}
+				comments = append(comments, comment{
+					lines: pf.lines[firstLine-1 : lastLine],
+					text:  getText(cg, exclude),
+					start: pf.fset.Position(cg.List[0].Slash),
+				})
+			}
+		}
+	}
+	return comments
+}
+
+// getDeclarationNames gets names of declared identifiers, which can be used
+// as the first word of the declaration comment. The result is a map from
+// the comment offset to the names. Comments of grouped declarations
+// are skipped, but comments of their specs are used instead.
+func (pf *parsedFile) getDeclarationNames() map[int][]string {
+	names := map[int][]string{}
+	add := func(cg *ast.CommentGroup, nn []string) {
+		if cg == nil || len(cg.List) == 0 || len(nn) == 0 {
+			return
+		}
+		names[pf.fset.Position(cg.List[0].Slash).Offset] = nn
+	}
+
+	for _, decl := range pf.file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			add(d.Doc, funcNames(d))
+		case *ast.GenDecl:
+			if d.Lparen == 0 && len(d.Specs) == 1 {
+				add(d.Doc, specNames(d.Specs[0]))
+				continue
+			}
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Doc, specNames(s))
+				case *ast.ValueSpec:
+					add(s.Doc, specNames(s))
+				}
+			}
+		}
+	}
+	return names
+}
+
 // getNoInlineComments gets all except inline comments.
 func (pf *parsedFile) getNoInlineComments(exclude []*regexp.Regexp) []comment {
 	var comments []comment
@@ -274,16 +436,6 @@ func getText(comment *ast.CommentGroup, exclude []*regexp.Regexp) (s string) {
 	return s[:len(s)-1] // trim last "\n"
 }
 
-// readFile reads file and returns its lines as strings.
-func readFile(filename string) ([]string, error) {
-	f, err := os.ReadFile(filepath.Clean(filename))
-	if err != nil {
-		return nil, err //nolint:wrapcheck
-	}
-
-	return strings.Split(string(f), "\n"), nil
-}
-
 // setDecl sets `decl` flag to comments which are declaration comments.
 func setDecl(comments, decl []comment) {
 	for _, d := range decl {
@@ -296,6 +448,127 @@ func setDecl(comments, decl []comment) {
 	}
 }
 
+// setNames sets names of declared identifiers to declaration comments.
+func setNames(comments []comment, names map[int][]string) {
+	for i, c := range comments {
+		if nn, ok := names[c.start.Offset]; ok {
+			comments[i].names = nn
+		}
+	}
+}
+
+// containsComment checks if the list contains the comment.
+func containsComment(comments []comment, c comment) bool {
+	for _, cc := range comments {
+		if cc.start == c.start {
+			return true
+		}
+	}
+	return false
+}
+
+// isExportedFunc checks if the function is exported. Methods are exported
+// if their receiver types are exported too.
+func isExportedFunc(d *ast.FuncDecl) bool {
+	if !d.Name.IsExported() {
+		return false
+	}
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return true
+	}
+	return isExportedField(&ast.Field{Type: receiverType(d.Recv.List[0].Type)})
+}
+
+// isExportedSpec checks if the spec declares any exported identifier.
+func isExportedSpec(spec ast.Spec) bool {
+	for _, name := range specNames(spec) {
+		if ast.IsExported(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExportedField checks if the field (or the interface method) is exported.
+// Embedded fields are exported if their type is exported.
+func isExportedField(f *ast.Field) bool {
+	if len(f.Names) == 0 {
+		typ := f.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		switch t := typ.(type) {
+		case *ast.Ident:
+			return t.IsExported()
+		case *ast.SelectorExpr:
+			return t.Sel.IsExported()
+		}
+		return false
+	}
+	for _, name := range f.Names {
+		if name.IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// funcNames returns possible names of a function for its comment. Methods
+// can also be named with their receiver type, e.g. `T.Method`.
+func funcNames(d *ast.FuncDecl) []string {
+	names := []string{d.Name.Name}
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return names
+	}
+	recv := d.Recv.List[0].Type
+	_, ptr := recv.(*ast.StarExpr)
+	ident, ok := receiverType(recv).(*ast.Ident)
+	if !ok {
+		return names
+	}
+	names = append(names,
+		ident.Name+"."+d.Name.Name,
+		"("+ident.Name+")."+d.Name.Name,
+	)
+	if ptr {
+		names = append(names, "(*"+ident.Name+")."+d.Name.Name)
+	}
+	return names
+}
+
+// receiverType returns the type of the method receiver without pointer
+// and type parameters, e.g. `T` for `*T[K, V]`.
+func receiverType(recv ast.Expr) ast.Expr {
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	// Skip type parameters of generic types
+	switch r := recv.(type) {
+	case *ast.IndexExpr:
+		recv = r.X
+	case *ast.IndexListExpr:
+		recv = r.X
+	}
+	return recv
+}
+
+// specNames returns names declared by the spec.
+func specNames(spec ast.Spec) []string {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return []string{s.Name.Name}
+	case *ast.ValueSpec:
+		names := make([]string, 0, len(s.Names))
+		for _, n := range s.Names {
+			if n.Name != "_" {
+				names = append(names, n.Name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
 // matchAny checks if string matches any of given regexps.
 func matchAny(s string, rr []*regexp.Regexp) bool {
 	for _, re := range rr {
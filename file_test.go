@@ -18,7 +18,7 @@ func TestGetComments(t *testing.T) {
 		t.Fatalf("Failed to parse input file: %v", err)
 	}
 
-	pf, err := newParsedFile(file, fset)
+	pf, err := newParsedFile(file, fset, nil)
 	if err != nil {
 		t.Fatalf("Failed to parse input file: %v", err)
 	}
@@ -52,7 +52,7 @@ func TestGetComments(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			comments := pf.getComments(tt.scope, nil)
+			comments := pf.getComments(tt.scope, nil, false)
 			var expected int
 			for _, c := range comments {
 				if linesContain(c.lines, "[NONE]") {
@@ -75,6 +75,150 @@ func TestGetComments(t *testing.T) {
 	}
 }
 
+func TestGetExportedComments(t *testing.T) {
+	src := `// Package example [YES].
+package example
+
+// Exported [YES].
+func Exported() {}
+
+// unexported [NO].
+func unexported() {}
+
+// Method [YES].
+func (T[K]) Method() {}
+
+// method [NO].
+func (*T[K]) method() {}
+
+// Method [NO].
+func (*t) Method() {}
+
+// T [YES].
+type T[K any] struct{}
+
+// t [NO].
+type t struct{}
+
+// Group [YES].
+const (
+	// A [YES].
+	A = 1
+	// b [NO].
+	b = 2
+)
+
+// Group [NO].
+var (
+	// a [NO].
+	a = 1
+	// b [NO].
+	b = 2
+)
+
+// A [YES].
+var _, A = 1, 2
+
+func main() {
+	// Inside [NO].
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse input file: %v", err)
+	}
+	pf, err := newParsedFile(file, fset, []byte(src))
+	if err != nil {
+		t.Fatalf("Failed to parse input file: %v", err)
+	}
+
+	comments := pf.getComments(ExportedScope, nil, false)
+	for _, c := range comments {
+		if !strings.Contains(c.text, "[YES]") {
+			t.Fatalf("Unexpected comment: %s", c.text)
+		}
+	}
+	if len(comments) != strings.Count(src, "[YES]") {
+		t.Fatalf("Wrong number of comments\n  expected: %d\n       got: %d",
+			strings.Count(src, "[YES]"), len(comments))
+	}
+}
+
+func TestGetFieldComments(t *testing.T) {
+	src := `package example
+
+// Exported is a struct [DECL].
+type Exported struct {
+	// Field is a field [FIELD].
+	Field int
+	// Embedded is an embedded field [FIELD].
+	*Embedded
+	// field is unexported.
+	field int
+
+	Inline int // inline comment
+}
+
+type (
+	// Iface is an interface [DECL].
+	Iface interface {
+		// Method is a method [FIELD].
+		Method()
+		// method is unexported.
+		method()
+	}
+
+	unexported struct {
+		// Field is a field of unexported type.
+		Field int
+	}
+)
+
+func main() {
+	type Local struct {
+		// Field is a field of local type.
+		Field int
+	}
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "main.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse input file: %v", err)
+	}
+	pf, err := newParsedFile(file, fset, []byte(src))
+	if err != nil {
+		t.Fatalf("Failed to parse input file: %v", err)
+	}
+
+	testCases := []struct {
+		name   string
+		scope  Scope
+		fields bool
+		count  int
+	}{
+		{name: "decl", scope: DeclScope, fields: false, count: 2},
+		{name: "decl with fields", scope: DeclScope, fields: true, count: 5},
+		{name: "all with fields", scope: AllScope, fields: true, count: 10},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := pf.getComments(tt.scope, nil, tt.fields)
+			if len(comments) != tt.count {
+				t.Fatalf("Wrong number of comments\n  expected: %d\n       got: %d",
+					tt.count, len(comments))
+			}
+			for _, c := range comments {
+				if strings.Contains(c.text, "[FIELD]") && !c.decl {
+					t.Fatalf("Field comment is not marked as declaration: %s", c.text)
+				}
+			}
+		})
+	}
+}
+
 func TestGetText(t *testing.T) {
 	testCases := []struct {
 		name    string
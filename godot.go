@@ -6,11 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/parser"
 	"go/token"
 	"os"
 	"regexp"
 	"sort"
-	"strings"
 )
 
 // NOTE: Line and column indexes are 1-based.
@@ -21,13 +21,53 @@ import (
 // Issue contains a description of linting error and a recommended replacement.
 type Issue struct {
 	Pos         token.Position
+	Rule        string // name of the rule, e.g. "period"
 	Message     string
-	Replacement string
+	Replacement string // the whole line with the fix applied, or the original line if there is no fix
+	Edits       []TextEdit
 }
 
-// Run runs this linter on the provided code.
+// TextEdit is a replacement of a range of bytes in the original file.
+// Unlike Issue.Replacement, edits from different issues on the same line
+// can be combined.
+type TextEdit struct {
+	Pos     token.Position // start of the range
+	End     token.Position // end of the range, not included
+	NewText string
+}
+
+// Run runs this linter on the provided code. The original source code is
+// read from the file.
 func Run(file *ast.File, fset *token.FileSet, settings Settings) ([]Issue, error) {
-	pf, err := newParsedFile(file, fset)
+	return run(file, fset, nil, settings)
+}
+
+// RunSource runs this linter on the provided source code. Unlike Run,
+// it doesn't read anything from disk, so the source may differ from
+// the file content, e.g. for unsaved editor buffers. The filename is used
+// only for issue positions.
+func RunSource(filename string, src []byte, settings Settings) ([]Issue, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse source: %w", err)
+	}
+	return run(file, fset, src, settings)
+}
+
+// run runs this linter on the provided code. If the source is nil, it is
+// read from the file.
+func run(file *ast.File, fset *token.FileSet, src []byte, settings Settings) ([]Issue, error) {
+	// Generated files can't be fixed manually, they are regenerated
+	if file != nil && !settings.CheckGenerated && ast.IsGenerated(file) {
+		return nil, nil
+	}
+	// Files without comments can only have missing comments
+	if file != nil && len(file.Comments) == 0 && !settings.MissingDoc.Enabled {
+		return nil, nil
+	}
+
+	pf, err := newParsedFile(file, fset, src)
 	if errors.Is(err, errEmptyInput) {
 		return nil, nil
 	}
@@ -43,8 +83,12 @@ func Run(file *ast.File, fset *token.FileSet, settings Settings) ([]Issue, error
 		}
 	}
 
-	comments := pf.getComments(settings.Scope, exclude)
+	comments := pf.getComments(settings.Scope, exclude, settings.Fields)
 	issues := checkComments(comments, settings)
+	if settings.MissingDoc.Enabled {
+		issues = append(issues, pf.checkMissingDoc(settings.MissingDoc)...)
+	}
+	issues = applyDirectives(issues, pf.getDirectives(), settings)
 	sortIssues(issues)
 
 	return issues, nil
@@ -57,33 +101,35 @@ func Fix(path string, file *ast.File, fset *token.FileSet, settings Settings) ([
 	if err != nil {
 		return nil, fmt.Errorf("read file: %w", err)
 	}
-	if len(content) == 0 {
+	return fix(file, fset, content, settings)
+}
+
+// FixSource fixes all issues in the provided source code and returns its new
+// version. Nothing is read from disk. The filename is used only for parsing.
+func FixSource(filename string, src []byte, settings Settings) ([]byte, error) {
+	if len(src) == 0 {
 		return nil, nil
 	}
-
-	issues, err := Run(file, fset, settings)
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
 	if err != nil {
-		return nil, fmt.Errorf("run linter: %w", err)
+		return nil, fmt.Errorf("parse source: %w", err)
 	}
+	return fix(file, fset, src, settings)
+}
 
-	// slice -> map
-	m := map[int]Issue{}
-	for _, iss := range issues {
-		m[iss.Pos.Line] = iss
+// fix fixes all issues in the content and returns its new version.
+func fix(file *ast.File, fset *token.FileSet, content []byte, settings Settings) ([]byte, error) {
+	if len(content) == 0 {
+		return nil, nil
 	}
 
-	// Replace lines from issues
-	fixed := make([]byte, 0, len(content))
-	for i, line := range strings.Split(string(content), "\n") {
-		newline := line
-		if iss, ok := m[i+1]; ok {
-			newline = iss.Replacement
-		}
-		fixed = append(fixed, []byte(newline+"\n")...)
+	issues, err := run(file, fset, content, settings)
+	if err != nil {
+		return nil, fmt.Errorf("run linter: %w", err)
 	}
-	fixed = fixed[:len(fixed)-1] // trim last "\n"
 
-	return fixed, nil
+	return applyEdits(content, issues), nil
 }
 
 // Replace rewrites original file with its fixed version.
@@ -105,6 +151,40 @@ func Replace(path string, file *ast.File, fset *token.FileSet, settings Settings
 	return nil
 }
 
+// applyEdits applies all edits from the issues to the content in one pass.
+// Edits that overlap with the previously applied ones are skipped.
+func applyEdits(content []byte, issues []Issue) []byte {
+	var edits []TextEdit
+	for _, iss := range issues {
+		edits = append(edits, iss.Edits...)
+	}
+	// Insertions go before replacements at the same offset, so both
+	// of them are applied
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Pos.Offset != edits[j].Pos.Offset {
+			return edits[i].Pos.Offset < edits[j].Pos.Offset
+		}
+		return edits[i].End.Offset < edits[j].End.Offset
+	})
+
+	fixed := make([]byte, 0, len(content))
+	last := 0 // end of the last applied edit
+	for i, e := range edits {
+		if e.Pos.Offset < last || e.End.Offset < e.Pos.Offset || e.End.Offset > len(content) {
+			continue // overlapping or broken edit
+		}
+		if i > 0 && e == edits[i-1] {
+			continue // duplicate
+		}
+		fixed = append(fixed, content[last:e.Pos.Offset]...)
+		fixed = append(fixed, e.NewText...)
+		last = e.End.Offset
+	}
+	fixed = append(fixed, content[last:]...)
+
+	return fixed
+}
+
 // sortIssues sorts by filename, line and column.
 func sortIssues(iss []Issue) {
 	sort.Slice(iss, func(i, j int) bool {
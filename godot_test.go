@@ -1,6 +1,7 @@
 package godot
 
 import (
+	"go/ast"
 	"go/parser"
 	"go/token"
 	"os"
@@ -146,6 +147,63 @@ func TestRun(t *testing.T) {
 	}
 }
 
+func TestRunSource(t *testing.T) {
+	t.Run("invalid source", func(t *testing.T) {
+		_, err := RunSource("main.go", []byte("package"), Settings{})
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+
+	t.Run("source differs from file", func(t *testing.T) {
+		// The file doesn't exist, so the source must not be read from disk
+		src := "package example\n\n// Sum sums two integers\nfunc Sum(a, b int) int {\n\treturn a + b\n}\n"
+		issues, err := RunSource(filepath.Join("testdata", "not-exists.go"), []byte(src), Settings{
+			Scope:  DeclScope,
+			Period: true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(issues) != 1 {
+			t.Fatalf("Wrong number of result issues\n  expected: %d\n       got: %d",
+				1, len(issues))
+		}
+		if issues[0].Pos.Line != 3 || issues[0].Pos.Column != 25 {
+			t.Fatalf("Wrong position: %s", issues[0].Pos)
+		}
+	})
+
+	t.Run("generated file", func(t *testing.T) {
+		src := "// Code generated by tool. DO NOT EDIT.\n\n" +
+			"package example\n\n// Sum sums two integers\nfunc Sum(a, b int) int {\n\treturn a + b\n}\n"
+
+		issues, err := RunSource("main.go", []byte(src), Settings{
+			Scope:  DeclScope,
+			Period: true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(issues) != 0 {
+			t.Fatalf("Unexpected issues in generated file: %v", issues)
+		}
+
+		issues, err = RunSource("main.go", []byte(src), Settings{
+			Scope:          DeclScope,
+			Period:         true,
+			CheckGenerated: true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(issues) != 1 {
+			t.Fatalf("Wrong number of result issues\n  expected: %d\n       got: %d",
+				1, len(issues))
+		}
+	})
+}
+
 func TestFix(t *testing.T) {
 	t.Run("file not found", func(t *testing.T) {
 		testFile := filepath.Join("testdata", "not-exists.go")
@@ -308,6 +366,130 @@ func TestFix(t *testing.T) {
 	})
 }
 
+func TestFixSource(t *testing.T) {
+	t.Run("empty source", func(t *testing.T) {
+		fixed, err := FixSource("main.go", nil, Settings{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fixed != nil {
+			t.Fatalf("Unexpected result: %s", string(fixed))
+		}
+	})
+
+	t.Run("invalid source", func(t *testing.T) {
+		_, err := FixSource("main.go", []byte("package"), Settings{})
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	})
+
+	t.Run("multiple issues on the same line", func(t *testing.T) {
+		src := "package example\n\n// Sum sums. two integers! and returns\nfunc Sum(a, b int) int {\n\treturn a + b\n}\n"
+		expected := "package example\n\n// Sum sums. Two integers! And returns.\nfunc Sum(a, b int) int {\n\treturn a + b\n}\n"
+
+		fixed, err := FixSource("main.go", []byte(src), Settings{
+			Scope:   AllScope,
+			Period:  true,
+			Capital: true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertEqualContent(t, expected, string(fixed))
+	})
+
+	t.Run("names of declared identifiers", func(t *testing.T) {
+		src := `package example
+
+// returns a sum.
+func Sum(a, b int) int { return a + b }
+
+// Do does nothing.
+func (c *Client) Do() {}
+
+// Client.Stop does nothing.
+func (c *Client) Stop() {}
+
+// A Client is a client.
+type Client struct{}
+
+// Group of constants.
+const (
+	// first constant.
+	One = 1
+	// Two is the second constant.
+	Two = 2
+)
+
+// unknown variables.
+var x, y int
+`
+		expected := `package example
+
+// Sum returns a sum.
+func Sum(a, b int) int { return a + b }
+
+// Do does nothing.
+func (c *Client) Do() {}
+
+// Client.Stop does nothing.
+func (c *Client) Stop() {}
+
+// A Client is a client.
+type Client struct{}
+
+// Group of constants.
+const (
+	// One first constant.
+	One = 1
+	// Two is the second constant.
+	Two = 2
+)
+
+// x unknown variables.
+var x, y int
+`
+
+		fixed, err := FixSource("main.go", []byte(src), Settings{
+			Scope: DeclScope,
+			Name:  true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertEqualContent(t, expected, string(fixed))
+	})
+
+	t.Run("source differs from file", func(t *testing.T) {
+		testFile := filepath.Join("testdata", "check", "main.go")
+		content, err := os.ReadFile(testFile)
+		if err != nil {
+			t.Fatalf("Failed to read test file %s: %v", testFile, err)
+		}
+		// Shift all lines, so the positions from the original file
+		// would be wrong
+		src := "// Header comment.\n\n" + string(content)
+
+		expected := strings.ReplaceAll(src, "[PERIOD_DECL]", "[PERIOD_DECL].")
+		expected = strings.ReplaceAll(expected, "non-capital-decl", "Non-capital-decl")
+
+		fixed, err := FixSource(testFile, []byte(src), Settings{
+			Scope:   DeclScope,
+			Exclude: testExclude,
+			Period:  true,
+			Capital: true,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		assertEqualContent(t, expected, string(fixed))
+	})
+}
+
 func TestReplace(t *testing.T) {
 	t.Run("file not found", func(t *testing.T) {
 		path := filepath.Join("testdata", "not-exists.go")
@@ -508,6 +690,151 @@ func TestReplace(t *testing.T) {
 	})
 }
 
+func TestIssueReplacement(t *testing.T) {
+	src := "package example\n" +
+		"\n" +
+		"//godot:unknown\n" +
+		"\n" +
+		"func Foo() {}\n" +
+		"\n" +
+		"// Bar calls [Baz]\n" +
+		"//\n" +
+		"// Deprecated:\n" +
+		"func Bar() {}\n"
+	settings := Settings{
+		Scope:      DeclScope,
+		Period:     true,
+		Deprecated: true,
+		MissingDoc: MissingDocSettings{Enabled: true},
+		PackageDoc: true,
+		DocLinks:   true,
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse input file: %v", err)
+	}
+	issues, err := RunSource("example.go", []byte(src), settings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pkgIssues, err := runPackage([]*ast.File{file}, fset, [][]byte{[]byte(src)}, nil, settings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	issues = append(issues, pkgIssues...)
+
+	// Issues without fixes keep the original line
+	rules := map[string]bool{}
+	lines := strings.Split(src, "\n")
+	for _, iss := range issues {
+		rules[iss.Rule] = true
+		switch {
+		case len(iss.Edits) == 0 && iss.Replacement != lines[iss.Pos.Line-1]:
+			t.Fatalf("Wrong replacement of %s issue\n  expected: %q\n       got: %q",
+				iss.Rule, lines[iss.Pos.Line-1], iss.Replacement)
+		case len(iss.Edits) > 0 && iss.Replacement == "":
+			t.Fatalf("Empty replacement of %s issue", iss.Rule)
+		}
+	}
+	for _, rule := range []string{
+		PeriodRule, DeprecatedRule, MissingDocRule,
+		PackageDocRule, DocLinkRule, DirectiveRule,
+	} {
+		if !rules[rule] {
+			t.Fatalf("No %s issue in %v", rule, issues)
+		}
+	}
+}
+
+func TestApplyEdits(t *testing.T) {
+	edit := func(start, end int, text string) TextEdit {
+		return TextEdit{
+			Pos:     token.Position{Offset: start},
+			End:     token.Position{Offset: end},
+			NewText: text,
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		content  string
+		issues   []Issue
+		expected string
+	}{
+		{
+			name:     "no edits",
+			content:  "hello, world",
+			issues:   []Issue{{}},
+			expected: "hello, world",
+		},
+		{
+			name:    "multiple edits in one issue",
+			content: "hello, world",
+			issues: []Issue{
+				{Edits: []TextEdit{edit(0, 1, "H"), edit(12, 12, ".")}},
+			},
+			expected: "Hello, world.",
+		},
+		{
+			name:    "edits from different issues",
+			content: "hello, world",
+			issues: []Issue{
+				{Edits: []TextEdit{edit(12, 12, ".")}},
+				{Edits: []TextEdit{edit(7, 8, "W")}},
+				{Edits: []TextEdit{edit(0, 1, "H")}},
+			},
+			expected: "Hello, World.",
+		},
+		{
+			name:    "overlapping edits",
+			content: "hello, world",
+			issues: []Issue{
+				{Edits: []TextEdit{edit(0, 5, "Bye")}},
+				{Edits: []TextEdit{edit(1, 2, "E")}},
+			},
+			expected: "Bye, world",
+		},
+		{
+			name:    "insertion and replacement at the same offset",
+			content: "hello, world",
+			issues: []Issue{
+				{Edits: []TextEdit{edit(0, 1, "H")}},
+				{Edits: []TextEdit{edit(0, 0, ">")}},
+			},
+			expected: ">Hello, world",
+		},
+		{
+			name:    "duplicate edits",
+			content: "hello, world",
+			issues: []Issue{
+				{Edits: []TextEdit{edit(12, 12, ".")}},
+				{Edits: []TextEdit{edit(12, 12, ".")}},
+			},
+			expected: "hello, world.",
+		},
+		{
+			name:    "edit out of range",
+			content: "hello, world",
+			issues: []Issue{
+				{Edits: []TextEdit{edit(12, 20, ".")}},
+			},
+			expected: "hello, world",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed := applyEdits([]byte(tt.content), tt.issues)
+			if string(fixed) != tt.expected {
+				t.Fatalf("Wrong result\n  expected: '%s'\n       got: '%s'",
+					tt.expected, string(fixed))
+			}
+		})
+	}
+}
+
 func assertEqualContent(t *testing.T, expected, content string) {
 	contentLines := strings.Split(content, "\n")
 	expectedLines := strings.Split(expected, "\n")
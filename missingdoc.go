@@ -0,0 +1,109 @@
+package godot
+
+import (
+	"fmt"
+	"go/ast"
+	"slices"
+	"strings"
+)
+
+// DefaultMissingDocMethods is a list of methods, that implement well-known
+// interfaces, and therefore don't need doc comments.
+var DefaultMissingDocMethods = []string{
+	"String", "GoString", "Format", "Error", "Unwrap", "Is", "As",
+	"MarshalJSON", "UnmarshalJSON", "MarshalText", "UnmarshalText",
+	"MarshalBinary", "UnmarshalBinary", "MarshalYAML", "UnmarshalYAML",
+	"Len", "Less", "Swap", "Read", "Write", "Close", "ServeHTTP",
+}
+
+// checkMissingDoc finds exported top level declarations without doc
+// comments.
+func (pf *parsedFile) checkMissingDoc(settings MissingDocSettings) []Issue {
+	filename := getFilename(pf.fset, pf.file)
+	if !settings.Tests && strings.HasSuffix(filename, "_test.go") {
+		return nil
+	}
+	if !settings.Main && pf.file.Name.Name == "main" {
+		return nil
+	}
+	skipMethods := settings.SkipMethods
+	if skipMethods == nil {
+		skipMethods = DefaultMissingDocMethods
+	}
+
+	var issues []Issue
+	report := func(name *ast.Ident) {
+		issues = append(issues, pf.missingDocIssue(name))
+	}
+	for _, decl := range pf.file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil || !isExportedFunc(d) {
+				continue
+			}
+			if d.Recv != nil && slices.Contains(skipMethods, d.Name.Name) {
+				continue
+			}
+			report(d.Name)
+		case *ast.GenDecl:
+			// Doc comment of a single declaration is attached to
+			// the declaration itself
+			if d.Lparen == 0 {
+				if d.Doc == nil && len(d.Specs) == 1 {
+					if name := exportedName(d.Specs[0]); name != nil {
+						report(name)
+					}
+				}
+				continue
+			}
+			for _, spec := range d.Specs {
+				name := exportedName(spec)
+				if name == nil {
+					continue
+				}
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if s.Doc == nil {
+						report(name)
+					}
+				case *ast.ValueSpec:
+					// Constants and variables can be documented with
+					// a comment of the whole block
+					if s.Doc == nil && d.Doc == nil {
+						report(name)
+					}
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// missingDocIssue creates an issue for the declared identifier.
+func (pf *parsedFile) missingDocIssue(name *ast.Ident) Issue {
+	pos := pf.fset.Position(name.Pos())
+	pos.Offset -= pos.Column - 1 // offset of the line start
+	return Issue{
+		Pos:         pos,
+		Rule:        MissingDocRule,
+		Message:     fmt.Sprintf(missingDocMessage, name.Name),
+		Replacement: pf.line(pos.Line),
+	}
+}
+
+// exportedName returns the first exported identifier declared by the spec.
+func exportedName(spec ast.Spec) *ast.Ident {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		if s.Name.IsExported() {
+			return s.Name
+		}
+	case *ast.ValueSpec:
+		for _, name := range s.Names {
+			if name.IsExported() {
+				return name
+			}
+		}
+	}
+	return nil
+}
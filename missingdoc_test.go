@@ -0,0 +1,136 @@
+package godot
+
+import (
+	"testing"
+)
+
+func TestCheckMissingDoc(t *testing.T) {
+	src := `package example
+
+func Exported() {} // [MISSING]
+
+func unexported() {}
+
+// Documented is documented.
+func Documented() {}
+
+type T struct{} // [MISSING]
+
+func (T) Method() {} // [MISSING]
+
+func (T) String() string { return "" }
+
+func (t) Method() {}
+
+type t struct{}
+
+var A, b = 1, 2 // [MISSING]
+
+var _, c = 1, 2
+
+// Consts are documented by the block.
+const (
+	C1 = 1
+	C2 = 2
+)
+
+const (
+	// D1 is documented.
+	D1 = 1
+	D2 = 2 // [MISSING]
+)
+
+type (
+	// X is documented.
+	X int
+	Y int // [MISSING]
+)
+
+func main() {
+	type Local int
+}
+`
+	testCases := []struct {
+		name     string
+		filename string
+		src      string
+		settings MissingDocSettings
+		lines    []int
+	}{
+		{
+			name:     "disabled",
+			filename: "example.go",
+			src:      src,
+			settings: MissingDocSettings{},
+			lines:    nil,
+		},
+		{
+			name:     "enabled",
+			filename: "example.go",
+			src:      src,
+			settings: MissingDocSettings{Enabled: true},
+			lines:    []int{3, 10, 12, 20, 33, 39},
+		},
+		{
+			name:     "custom methods",
+			filename: "example.go",
+			src:      src,
+			settings: MissingDocSettings{Enabled: true, SkipMethods: []string{"Method"}},
+			lines:    []int{3, 10, 14, 20, 33, 39},
+		},
+		{
+			name:     "skip tests",
+			filename: "example_test.go",
+			src:      src,
+			settings: MissingDocSettings{Enabled: true},
+			lines:    nil,
+		},
+		{
+			name:     "check tests",
+			filename: "example_test.go",
+			src:      src,
+			settings: MissingDocSettings{Enabled: true, Tests: true},
+			lines:    []int{3, 10, 12, 20, 33, 39},
+		},
+		{
+			name:     "skip main",
+			filename: "main.go",
+			src:      "package main\n\nfunc Exported() {}\n",
+			settings: MissingDocSettings{Enabled: true},
+			lines:    nil,
+		},
+		{
+			name:     "check main",
+			filename: "main.go",
+			src:      "package main\n\nfunc Exported() {}\n",
+			settings: MissingDocSettings{Enabled: true, Main: true},
+			lines:    []int{3},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := RunSource(tt.filename, []byte(tt.src), Settings{
+				Scope:      DeclScope,
+				MissingDoc: tt.settings,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(issues) != len(tt.lines) {
+				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d",
+					len(tt.lines), len(issues))
+			}
+			for i, iss := range issues {
+				if iss.Pos.Line != tt.lines[i] {
+					t.Fatalf("Wrong line\n  expected: %d\n       got: %d",
+						tt.lines[i], iss.Pos.Line)
+				}
+				if iss.Rule != MissingDocRule {
+					t.Fatalf("Wrong rule\n  expected: %s\n       got: %s",
+						MissingDocRule, iss.Rule)
+				}
+			}
+		})
+	}
+}
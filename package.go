@@ -0,0 +1,185 @@
+package godot
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Error messages.
+const (
+	noPackageDocMessage       = "Package should have a package comment"
+	multiplePackageDocMessage = "Package comment should be in one file only"
+	packageDocPrefixMessage   = "Package comment should begin with \"Package %s\""
+	commandDocPrefixMessage   = "Package comment should begin with \"Command\" and the command name"
+	packageDocPeriodMessage   = "Package comment should end in a period"
+)
+
+// RunPackage runs package level checks on files of a single package, e.g.
+// the package comment check. Unlike Run, it doesn't check every comment
+// of the files, so both functions should be used to lint the package.
+// The original source code is read from the files.
+func RunPackage(files []*ast.File, fset *token.FileSet, settings Settings) ([]Issue, error) {
+	return runPackage(files, fset, nil, nil, settings)
+}
+
+// RunTypedPackage is the same as RunPackage, but it uses type information
+// of the package to check doc links to imported packages.
+func RunTypedPackage(files []*ast.File, fset *token.FileSet, pkg *types.Package, settings Settings) ([]Issue, error) {
+	return runPackage(files, fset, nil, pkg, settings)
+}
+
+// runPackage runs package level checks. Sources are set in the same order
+// as files, if the source is nil, it is read from the file. Type information
+// is optional.
+func runPackage(files []*ast.File, fset *token.FileSet, srcs [][]byte, pkg *types.Package, settings Settings) ([]Issue, error) {
+	if (!settings.PackageDoc && !settings.DocLinks) || fset == nil {
+		return nil, nil
+	}
+
+	// Test files are not a part of the documentation. Generated files are
+	// not checked, but the package comment may be there.
+	pfs := make([]*parsedFile, 0, len(files))
+	generatedDoc := false
+	for i, file := range files {
+		if file == nil || strings.HasSuffix(getFilename(fset, file), "_test.go") {
+			continue
+		}
+		if !settings.CheckGenerated && ast.IsGenerated(file) {
+			generatedDoc = generatedDoc || (file.Doc != nil && len(file.Doc.List) > 0)
+			continue
+		}
+		var src []byte
+		if i < len(srcs) {
+			src = srcs[i]
+		}
+		pf, err := newParsedFile(file, fset, src)
+		if errors.Is(err, errEmptyInput) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse input file: %w", err)
+		}
+		pfs = append(pfs, pf)
+	}
+	if len(pfs) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(pfs, func(i, j int) bool {
+		return getFilename(fset, pfs[i].file) < getFilename(fset, pfs[j].file)
+	})
+
+	exclude := make([]*regexp.Regexp, len(settings.Exclude))
+	for i := 0; i < len(settings.Exclude); i++ {
+		var err error
+		exclude[i], err = regexp.Compile(settings.Exclude[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regexp: %w", err)
+		}
+	}
+
+	// Generated files are not checked, but their declarations can be
+	// referred by doc links
+	var syms symbols
+	if settings.DocLinks {
+		syms = getSymbols(files, fset)
+	}
+
+	var issues []Issue
+	for _, pf := range pfs {
+		var fileIssues []Issue
+		if settings.PackageDoc {
+			fileIssues = append(fileIssues, checkPackageDoc(pf, pfs, generatedDoc, exclude, settings)...)
+		}
+		if settings.DocLinks {
+			fileIssues = append(fileIssues, checkDocLinks(pf, syms, pkg, exclude, settings)...)
+		}
+		issues = append(issues, filterDirectives(fileIssues, pf.getDirectives())...)
+	}
+	sortIssues(issues)
+
+	return issues, nil
+}
+
+// checkPackageDoc checks the package comment of the file. The package
+// should have exactly one package comment, which begins with "Package"
+// and the package name, or "Command" for main packages, and ends
+// in a period. The package comment in a generated file is not checked, but
+// the package is not reported as missing the comment.
+func checkPackageDoc(pf *parsedFile, pfs []*parsedFile, generatedDoc bool, exclude []*regexp.Regexp, settings Settings) []Issue {
+	// Find the first file with the package comment
+	var first *parsedFile
+	for _, p := range pfs {
+		if p.file.Doc != nil && len(p.file.Doc.List) > 0 {
+			first = p
+			break
+		}
+	}
+
+	cg := pf.file.Doc
+	switch {
+	case first == nil && pf == pfs[0] && !generatedDoc:
+		return []Issue{pf.packageIssue(pf.file.Package, noPackageDocMessage)}
+	case cg == nil || len(cg.List) == 0:
+		return nil
+	case pf != first:
+		return []Issue{pf.packageIssue(cg.Pos(), multiplePackageDocMessage)}
+	}
+
+	var issues []Issue
+
+	// Check the first word
+	name := pf.file.Name.Name
+	words := strings.Fields(cg.Text())
+	switch {
+	case name == "main":
+		if len(words) < 2 || words[0] != "Command" {
+			issues = append(issues, pf.packageIssue(cg.Pos(), commandDocPrefixMessage))
+		}
+	default:
+		if len(words) < 2 || words[0] != "Package" || !hasWordPrefix(words[1], []string{name}) {
+			msg := fmt.Sprintf(packageDocPrefixMessage, name)
+			issues = append(issues, pf.packageIssue(cg.Pos(), msg))
+		}
+	}
+
+	// Check the period, unless the package comment is already checked
+	// by the period rule
+	if settings.Period && settings.Scope != DeclScope {
+		return issues
+	}
+	firstLine := pf.fset.Position(cg.Pos()).Line
+	lastLine := pf.fset.Position(cg.End()).Line
+	if firstLine < 1 || lastLine < firstLine || lastLine > len(pf.lines) {
+		return issues // broken consistency, probably by the `//line` directive
+	}
+	c := comment{
+		lines: append([]string(nil), pf.lines[firstLine-1:lastLine]...),
+		text:  getText(cg, exclude),
+		start: pf.fset.Position(cg.List[0].Slash),
+	}
+	if iss := checkPeriod(c, settings.endings()); iss != nil {
+		iss.Rule = PackageDocRule
+		iss.Message = packageDocPeriodMessage
+		issues = append(issues, *iss)
+	}
+
+	return issues
+}
+
+// packageIssue creates an issue of the package comment rule.
+func (pf *parsedFile) packageIssue(p token.Pos, msg string) Issue {
+	pos := pf.fset.Position(p)
+	pos.Offset -= pos.Column - 1 // offset of the line start
+	return Issue{
+		Pos:         pos,
+		Rule:        PackageDocRule,
+		Message:     msg,
+		Replacement: pf.line(pos.Line),
+	}
+}
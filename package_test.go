@@ -0,0 +1,166 @@
+package godot
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestRunPackage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		settings Settings
+		issues   []string // "file:line: message"
+	}{
+		{
+			name: "disabled",
+			files: map[string]string{
+				"a.go": "package example\n",
+			},
+			settings: Settings{Scope: DeclScope},
+			issues:   nil,
+		},
+		{
+			name: "valid",
+			files: map[string]string{
+				"a.go":      "package example\n",
+				"doc.go":    "// Package example is an example.\npackage example\n",
+				"a_test.go": "// Package example is a test.\npackage example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   nil,
+		},
+		{
+			name: "no package comment",
+			files: map[string]string{
+				"b.go":      "package example\n",
+				"a.go":      "// Not a package comment.\n\npackage example\n",
+				"a_test.go": "// Package example is a test.\npackage example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   []string{"a.go:3: " + noPackageDocMessage},
+		},
+		{
+			name: "multiple package comments",
+			files: map[string]string{
+				"a.go": "// Package example is an example.\npackage example\n",
+				"b.go": "// Package example is an example.\npackage example\n",
+				"c.go": "package example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   []string{"b.go:1: " + multiplePackageDocMessage},
+		},
+		{
+			name: "wrong prefix",
+			files: map[string]string{
+				"a.go": "// Example is an example.\npackage example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   []string{"a.go:1: Package comment should begin with \"Package example\""},
+		},
+		{
+			name: "wrong package name",
+			files: map[string]string{
+				"a.go": "// Package examples is an example.\npackage example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   []string{"a.go:1: Package comment should begin with \"Package example\""},
+		},
+		{
+			name: "command",
+			files: map[string]string{
+				"main.go": "// Command tool is a tool.\npackage main\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   nil,
+		},
+		{
+			name: "wrong command prefix",
+			files: map[string]string{
+				"main.go": "// Package main is a tool.\npackage main\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   []string{"main.go:1: " + commandDocPrefixMessage},
+		},
+		{
+			name: "no period",
+			files: map[string]string{
+				"a.go": "// Package example is an example\npackage example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   []string{"a.go:1: " + packageDocPeriodMessage},
+		},
+		{
+			name: "no period checked by the period rule",
+			files: map[string]string{
+				"a.go": "// Package example is an example\npackage example\n",
+			},
+			settings: Settings{Scope: TopLevelScope, Period: true, PackageDoc: true},
+			issues:   nil,
+		},
+		{
+			name: "directive",
+			files: map[string]string{
+				"a.go": "//godot:ignore package-doc\n// Example is an example\npackage example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   nil,
+		},
+		{
+			name: "generated file",
+			files: map[string]string{
+				"a.go": "// Code generated by tool. DO NOT EDIT.\n\n// Example is an example.\npackage example\n",
+				"b.go": "// Package example is an example.\npackage example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   nil,
+		},
+		{
+			name: "package comment in generated file",
+			files: map[string]string{
+				"a.go":       "package example\n",
+				"alldocs.go": "// Code generated by tool. DO NOT EDIT.\n\n// Package example is an example.\npackage example\n",
+			},
+			settings: Settings{Scope: DeclScope, PackageDoc: true},
+			issues:   nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			var files []*ast.File
+			var srcs [][]byte
+			for name, src := range tt.files {
+				f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+				if err != nil {
+					t.Fatalf("Failed to parse input file: %v", err)
+				}
+				files = append(files, f)
+				srcs = append(srcs, []byte(src))
+			}
+
+			issues, err := runPackage(files, fset, srcs, nil, tt.settings)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(issues) != len(tt.issues) {
+				t.Fatalf("Wrong number of issues\n  expected: %d\n       got: %d (%v)",
+					len(tt.issues), len(issues), issues)
+			}
+			for i, iss := range issues {
+				got := filepath.Base(iss.Pos.Filename) + ":" + strconv.Itoa(iss.Pos.Line) + ": " + iss.Message
+				if got != tt.issues[i] {
+					t.Fatalf("Wrong issue\n  expected: %s\n       got: %s", tt.issues[i], got)
+				}
+				if iss.Rule != PackageDocRule {
+					t.Fatalf("Wrong rule\n  expected: %s\n       got: %s", PackageDocRule, iss.Rule)
+				}
+			}
+		})
+	}
+}
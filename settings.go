@@ -1,8 +1,14 @@
 package godot
 
+import (
+	"fmt"
+	"slices"
+)
+
 // Settings contains linter settings.
 type Settings struct {
-	// Which comments to check (top level declarations, top level, all).
+	// Which comments to check (top level declarations, exported
+	// declarations, top level, all).
 	Scope Scope
 
 	// Regexp for excluding particular comment lines from check.
@@ -11,8 +17,128 @@ type Settings struct {
 	// Check periods at the end of sentences.
 	Period bool
 
+	// Check periods at the end of list items in declaration comments.
+	// List items are not checked by default.
+	ListPeriod bool `yaml:"list-period"`
+
 	// Check that first letter of each sentence is capital.
 	Capital bool
+
+	// Check that declaration comments begin with the name of the declared
+	// identifier.
+	Name bool
+
+	// Check that deprecation notices in declaration comments are separate
+	// paragraphs, that begin with "Deprecated:".
+	Deprecated bool
+
+	// Check that lists and code blocks in declaration comments are
+	// separated from the preceding text by an empty line.
+	BlankLine bool `yaml:"blank-line"`
+
+	// Check that the text of line comments is separated from "//" by
+	// a space. Directives like "//go:generate" are skipped.
+	Space bool
+
+	// Check that comments don't contain the same word twice in a row,
+	// e.g. "the the".
+	DuplicateWords bool `yaml:"duplicate-words"`
+
+	// Check doc comments of exported fields of exported struct types and
+	// exported methods of exported interface types in addition to the scope.
+	Fields bool
+
+	// Report exported declarations without doc comments.
+	MissingDoc MissingDocSettings `yaml:"missing-doc"`
+
+	// Check that the package has exactly one package comment, that begins
+	// with "Package <name>" ("Command" for main packages) and ends in
+	// a period. This check is run only by RunPackage.
+	PackageDoc bool `yaml:"package-doc"`
+
+	// Check that doc links in comments, e.g. [Name], [pkg.Name] or
+	// [*T.Method], refer to existing identifiers. Links to other packages
+	// are checked only if type information is available. This check is
+	// run only by RunPackage and RunTypedPackage.
+	DocLinks bool `yaml:"doc-links"`
+
+	// Additional valid sentence endings for the period check, e.g. ":"
+	// or "…". If ReplaceEndings is set, the default list is replaced.
+	Endings        []string
+	ReplaceEndings bool `yaml:"replace-endings"`
+
+	// Additional abbreviations for the capital letters check, e.g. "vs."
+	// or "cf.". If ReplaceAbbreviations is set, the default list is
+	// replaced.
+	Abbreviations        []string
+	ReplaceAbbreviations bool `yaml:"replace-abbreviations"`
+
+	// Check generated files, i.e. files with a "Code generated ... DO NOT
+	// EDIT." comment. Generated files are skipped by default.
+	CheckGenerated bool `yaml:"check-generated"`
+}
+
+// MissingDocSettings contains settings of the missing doc comments check.
+type MissingDocSettings struct {
+	// Report exported declarations without doc comments.
+	Enabled bool
+
+	// Check test files.
+	Tests bool
+
+	// Check main packages.
+	Main bool
+
+	// Methods, that don't need doc comments. If nil,
+	// DefaultMissingDocMethods is used.
+	SkipMethods []string `yaml:"skip-methods,omitempty"`
+}
+
+// enabled checks if the rule is enabled.
+func (s Settings) enabled(rule string) bool {
+	switch rule {
+	case PeriodRule:
+		return s.Period
+	case CapitalRule:
+		return s.Capital
+	case NameRule:
+		return s.Name
+	case DeprecatedRule:
+		return s.Deprecated
+	case BlankLineRule:
+		return s.BlankLine
+	case SpaceRule:
+		return s.Space
+	case DuplicateWordsRule:
+		return s.DuplicateWords
+	case MissingDocRule:
+		return s.MissingDoc.Enabled
+	case PackageDocRule:
+		return s.PackageDoc
+	case DocLinkRule:
+		return s.DocLinks
+	default:
+		return false
+	}
+}
+
+// endings returns the list of valid sentence endings.
+func (s Settings) endings() []string {
+	if s.ReplaceEndings {
+		// Special replacer is an internal marker of code examples, that
+		// should never be checked
+		return append(slices.Clone(s.Endings), specialReplacer)
+	}
+	return append(slices.Clone(lastChars), s.Endings...)
+}
+
+// abbreviationList returns the list of abbreviations, that are excluded
+// from the capital letters check.
+func (s Settings) abbreviationList() []string {
+	if s.ReplaceAbbreviations {
+		return s.Abbreviations
+	}
+	return append(slices.Clone(abbreviations), s.Abbreviations...)
 }
 
 // Scope sets which comments should be checked.
@@ -22,6 +148,9 @@ type Scope string
 const (
 	// DeclScope is for top level declaration comments.
 	DeclScope Scope = "declarations"
+	// ExportedScope is for package comment and comments of exported
+	// top level declarations.
+	ExportedScope Scope = "exported"
 	// TopLevelScope is for all top level comments.
 	TopLevelScope Scope = "toplevel"
 	// NoInlineScope is for all except inline comments.
@@ -29,3 +158,14 @@ const (
 	// AllScope is for all comments.
 	AllScope Scope = "all"
 )
+
+// ParseScope converts a string to a scope, and returns an error if the scope
+// is unknown.
+func ParseScope(s string) (Scope, error) {
+	switch scope := Scope(s); scope {
+	case DeclScope, ExportedScope, TopLevelScope, NoInlineScope, AllScope:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("unknown scope '%s'", s)
+	}
+}
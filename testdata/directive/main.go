@@ -0,0 +1,51 @@
+// Package directive is a test for godot directives.
+package directive
+
+// Ignored comment [PASS]
+//
+//godot:ignore
+func Ignored() {}
+
+// Ignored comment. with the directive at the end [PASS]
+//
+//godot:ignore
+func IgnoredEnd() {}
+
+// Not ignored comment. for period [PERIOD]
+//
+//godot:ignore capital
+func IgnoredCapital() {}
+
+// Ignored block [PASS]
+//
+//godot:ignore-next-block
+func IgnoredBlock() {
+	// Inner comment [PASS]
+}
+
+// Not ignored block [PERIOD]
+func NotIgnoredBlock() {
+	// Inner comment [PERIOD]
+}
+
+// Disabled region [PASS]
+//
+//godot:disable period
+func Disabled() {}
+
+// Disabled region. not capital [CAPITAL]
+func DisabledCapital() {}
+
+//godot:enable period
+
+// Enabled region [PERIOD]
+func Enabled() {}
+
+//godot:ignore
+
+//godot:unknown
+
+// Directive for the disabled rule is not reported [PERIOD]
+//
+//godot:ignore name
+func DisabledRule() {}
@@ -0,0 +1,21 @@
+// Package analyzer is a test for go/analysis analyzer.
+package analyzer
+
+var _ = 0 // want +2 "Comment should end in a period"
+
+// Sum sums two integers
+func Sum(a, b int) int {
+	return a + b // inline comment
+}
+
+var _ = 0 // want +2 "Comment should end in a period"
+
+/* Mult multiplies two integers */
+func Mult(a, b int) int {
+	return a * b
+}
+
+// Div divides two integers.
+func Div(a, b int) int {
+	return a / b
+}